@@ -0,0 +1,157 @@
+package cloudfront
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfront"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfrontorigins"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awselasticloadbalancingv2"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// -----------------------------------------------------------------------------
+// Configuración del origen ALB
+// -----------------------------------------------------------------------------
+type ALBOriginConfig struct {
+	// HTTPSOnly fuerza HTTPS (TLS 1.2) entre CloudFront y el ALB.
+	// Requiere un listener HTTPS con certificado válido para el Host reenviado.
+	// Por defecto: false (HTTP_ONLY hacia el listener del puerto 80)
+	HTTPSOnly bool
+
+	// Puertos del listener (opcional). Por defecto: 80 / 443
+	HTTPPort  *float64
+	HTTPSPort *float64
+
+	// Header secreto que CloudFront agrega a cada request hacia el ALB.
+	// El listener del ALB debe tener una regla que solo acepte requests con
+	// este header/valor, bloqueando el acceso directo que evita CloudFront.
+	// OriginSecretHeaderName por defecto: "X-Origin-Verify"
+	OriginSecretHeaderName string
+	OriginSecretValue      string
+
+	// ALB secundario para failover (opcional). Cuando el primario responde con
+	// alguno de FailoverStatusCodes, CloudFront reintenta contra este ALB.
+	// Nota: los origin groups solo soportan GET, HEAD y OPTIONS.
+	FailoverLoadBalancer awselasticloadbalancingv2.IApplicationLoadBalancer
+
+	// Códigos HTTP que disparan el failover. Por defecto: 500, 502, 503, 504
+	FailoverStatusCodes []float64
+
+	// Timeout de lectura del origen (opcional). Por defecto: 30 segundos
+	ReadTimeout awscdk.Duration
+}
+
+// ALBCloudFrontStrategy crea distribuciones CloudFront delante de Application
+// Load Balancers (aplicaciones en contenedores ECS/EKS o instancias EC2).
+//
+// Características:
+//   - Cache deshabilitado por defecto (contenido dinámico)
+//   - Reenvía todos los headers del viewer, incluido Host (routing por host en el ALB)
+//   - Origen HTTP u HTTPS-only con TLS 1.2
+//   - Header secreto de origen para que el ALB rechace tráfico que no venga de CloudFront
+//   - Failover opcional a un segundo ALB mediante origin group
+type ALBCloudFrontStrategy struct{}
+
+func (a *ALBCloudFrontStrategy) Build(scope constructs.Construct, id string, props CloudFrontPropertiesV2) awscloudfront.Distribution {
+	// =============================================================================
+	// 1. VALIDACIÓN BÁSICA
+	// =============================================================================
+	if props.LoadBalancer == nil {
+		panic("ALBCloudFrontStrategy requiere un Application Load Balancer (props.LoadBalancer no puede ser nil)")
+	}
+
+	// =============================================================================
+	// 2. CONFIGURAR DISTRIBUTION PROPS
+	// =============================================================================
+	distributionProps := newDistributionProps(props)
+
+	// SSL/TLS y dominios personalizados (opcional)
+	applyCustomDomain(scope, id, props, distributionProps)
+
+	// Logging y recursos compartidos por los behaviors (real-time logs, key group)
+	shared := newSharedBehaviorConfig(scope, id, props, distributionProps)
+
+	// =============================================================================
+	// 3. DEFAULT BEHAVIOR
+	// =============================================================================
+	distributionProps.DefaultBehavior = newDefaultBehavior(scope, id, props, shared)
+
+	// =============================================================================
+	// 4. WAF (si aplica)
+	// =============================================================================
+	applyWebAcl(props, distributionProps)
+
+	// =============================================================================
+	// 5. CREAR DISTRIBUTION
+	// =============================================================================
+	distribution := awscloudfront.NewDistribution(scope, jsii.String(fmt.Sprintf("%s-Distribution", id)), distributionProps)
+
+	// Registros alias A/AAAA en Route53 (si hay HostedZone)
+	addAliasRecords(scope, id, props, distribution)
+
+	// =============================================================================
+	// 6. BEHAVIORS ADICIONALES (routing por path pattern)
+	// =============================================================================
+	addAdditionalBehaviors(scope, id, distribution, props, shared)
+
+	return distribution
+}
+
+// newALBOriginWithFailover crea el origen ALB y, si hay FailoverLoadBalancer,
+// lo envuelve en un origin group con el ALB secundario como fallback.
+func newALBOriginWithFailover(loadBalancer awselasticloadbalancingv2.IApplicationLoadBalancer, config *ALBOriginConfig) awscloudfront.IOrigin {
+	if config == nil {
+		config = &ALBOriginConfig{}
+	}
+
+	origin := newALBOrigin(loadBalancer, config)
+	if config.FailoverLoadBalancer == nil {
+		return origin
+	}
+
+	statusCodes := config.FailoverStatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = []float64{500, 502, 503, 504}
+	}
+	var codes []*float64
+	for _, c := range statusCodes {
+		codes = append(codes, jsii.Number(c))
+	}
+
+	return awscloudfrontorigins.NewOriginGroup(&awscloudfrontorigins.OriginGroupProps{
+		PrimaryOrigin:       origin,
+		FallbackOrigin:      newALBOrigin(config.FailoverLoadBalancer, config),
+		FallbackStatusCodes: &codes,
+	})
+}
+
+// newALBOrigin crea un origen LoadBalancerV2 aplicando protocolo y header secreto.
+func newALBOrigin(loadBalancer awselasticloadbalancingv2.IApplicationLoadBalancer, config *ALBOriginConfig) awscloudfront.IOrigin {
+	originProps := &awscloudfrontorigins.LoadBalancerV2OriginProps{
+		ProtocolPolicy: awscloudfront.OriginProtocolPolicy_HTTP_ONLY,
+		HttpPort:       config.HTTPPort,
+		HttpsPort:      config.HTTPSPort,
+		ReadTimeout:    config.ReadTimeout,
+	}
+
+	if config.HTTPSOnly {
+		originProps.ProtocolPolicy = awscloudfront.OriginProtocolPolicy_HTTPS_ONLY
+		originProps.OriginSslProtocols = &[]awscloudfront.OriginSslPolicy{
+			awscloudfront.OriginSslPolicy_TLS_V1_2,
+		}
+	}
+
+	if config.OriginSecretValue != "" {
+		headerName := config.OriginSecretHeaderName
+		if headerName == "" {
+			headerName = "X-Origin-Verify"
+		}
+		originProps.CustomHeaders = &map[string]*string{
+			headerName: jsii.String(config.OriginSecretValue),
+		}
+	}
+
+	return awscloudfrontorigins.NewLoadBalancerV2Origin(loadBalancer, originProps)
+}
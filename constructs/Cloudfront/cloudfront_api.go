@@ -0,0 +1,64 @@
+package cloudfront
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfront"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// APICloudFrontStrategy crea distribuciones CloudFront delante de APIs HTTP
+// (API Gateway REST/HTTP, Function URLs o cualquier origen HTTP personalizado).
+//
+// A diferencia de S3CloudFrontStrategy:
+//   - El cache está deshabilitado por defecto (CACHING_DISABLED)
+//   - Se reenvían todos los headers del viewer excepto Host (API Gateway valida el Host)
+//   - Se permiten todos los métodos HTTP (GET, HEAD, OPTIONS, PUT, PATCH, POST, DELETE)
+//   - No hay default root object ni reescritura SPA de errores 403/404
+type APICloudFrontStrategy struct{}
+
+func (a *APICloudFrontStrategy) Build(scope constructs.Construct, id string, props CloudFrontPropertiesV2) awscloudfront.Distribution {
+	// =============================================================================
+	// 1. VALIDACIÓN BÁSICA
+	// =============================================================================
+	if props.ApiOrigin == nil {
+		panic("APICloudFrontStrategy requiere un origen API (props.ApiOrigin no puede ser nil)")
+	}
+
+	// =============================================================================
+	// 2. CONFIGURAR DISTRIBUTION PROPS
+	// =============================================================================
+	distributionProps := newDistributionProps(props)
+
+	// SSL/TLS y dominios personalizados (opcional)
+	applyCustomDomain(scope, id, props, distributionProps)
+
+	// Logging y recursos compartidos por los behaviors (real-time logs, key group)
+	shared := newSharedBehaviorConfig(scope, id, props, distributionProps)
+
+	// =============================================================================
+	// 3. DEFAULT BEHAVIOR
+	// =============================================================================
+	distributionProps.DefaultBehavior = newDefaultBehavior(scope, id, props, shared)
+
+	// =============================================================================
+	// 4. WAF (si aplica)
+	// =============================================================================
+	applyWebAcl(props, distributionProps)
+
+	// =============================================================================
+	// 5. CREAR DISTRIBUTION
+	// =============================================================================
+	distribution := awscloudfront.NewDistribution(scope, jsii.String(fmt.Sprintf("%s-Distribution", id)), distributionProps)
+
+	// Registros alias A/AAAA en Route53 (si hay HostedZone)
+	addAliasRecords(scope, id, props, distribution)
+
+	// =============================================================================
+	// 6. BEHAVIORS ADICIONALES (routing por path pattern)
+	// =============================================================================
+	addAdditionalBehaviors(scope, id, distribution, props, shared)
+
+	return distribution
+}
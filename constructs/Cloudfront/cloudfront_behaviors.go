@@ -0,0 +1,308 @@
+package cloudfront
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfront"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfrontorigins"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awselasticloadbalancingv2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsiam"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awss3"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// -----------------------------------------------------------------------------
+// Behaviors adicionales: routing por path pattern hacia cualquier OriginType
+// -----------------------------------------------------------------------------
+
+// BehaviorConfig define un cache behavior adicional de la distribución.
+// Cada behavior enruta un path pattern (ej. "/api/*", "/assets/*") hacia su
+// propio origen, con cache policy y métodos permitidos independientes.
+type BehaviorConfig struct {
+	// Path pattern del behavior (obligatorio). Ej: "/api/*"
+	PathPattern string
+
+	// Tipo de origen del behavior (obligatorio)
+	OriginType OriginType
+
+	// Recurso de origen (solo uno según OriginType)
+	S3Bucket     awss3.IBucket
+	ApiOrigin    awscloudfront.IOrigin
+	LoadBalancer awselasticloadbalancingv2.IApplicationLoadBalancer
+	ALBOrigin    *ALBOriginConfig
+
+	// Overrides opcionales (por defecto se usan los del OriginType)
+	CachePolicy          awscloudfront.ICachePolicy
+	OriginRequestPolicy  awscloudfront.IOriginRequestPolicy
+	AllowedMethods       awscloudfront.AllowedMethods
+	ViewerProtocolPolicy awscloudfront.ViewerProtocolPolicy
+
+	// Políticas personalizadas creadas para este behavior (opcional).
+	// Tienen prioridad sobre CachePolicy / OriginRequestPolicy.
+	CachePolicyOptions           *CachePolicyOptions
+	OriginRequestPolicyOptions   *OriginRequestPolicyOptions
+	ResponseHeadersPolicyOptions *ResponseHeadersPolicyOptions
+
+	// Funciones edge del behavior (CloudFront Functions o Lambda@Edge).
+	// Una por event type.
+	EdgeFunctions []EdgeFunction
+
+	// Exige signed URLs/cookies validadas con el key group de props.SignedAccess
+	RequireSignedAccess bool
+}
+
+// sharedBehaviorConfig agrupa recursos a nivel distribución que usan todos los
+// behaviors (DefaultBehavior y AdditionalBehaviors).
+type sharedBehaviorConfig struct {
+	realtimeLogConfig awscloudfront.IRealtimeLogConfig
+	keyGroup          awscloudfront.IKeyGroup
+}
+
+// newSharedBehaviorConfig configura logging sobre las DistributionProps y crea
+// los recursos compartidos por los behaviors (real-time logs, key group).
+func newSharedBehaviorConfig(scope constructs.Construct, id string, props CloudFrontPropertiesV2, distributionProps *awscloudfront.DistributionProps) sharedBehaviorConfig {
+	return sharedBehaviorConfig{
+		realtimeLogConfig: applyLogging(scope, id, props, distributionProps),
+		keyGroup:          newSignedAccessKeyGroup(scope, id, props),
+	}
+}
+
+// apply asigna los recursos compartidos a las opciones de un behavior.
+// requireSignedAccess exige signed URLs/cookies validadas con el key group.
+func (c sharedBehaviorConfig) apply(id string, options *awscloudfront.AddBehaviorOptions, requireSignedAccess bool) {
+	if c.realtimeLogConfig != nil {
+		options.RealtimeLogConfig = c.realtimeLogConfig
+	}
+
+	if requireSignedAccess {
+		if c.keyGroup == nil {
+			panic(fmt.Sprintf("%s: RequireSignedAccess requiere props.SignedAccess", id))
+		}
+		options.TrustedKeyGroups = &[]awscloudfront.IKeyGroup{c.keyGroup}
+	}
+}
+
+// originTarget agrupa el tipo de origen y los recursos necesarios para crearlo.
+type originTarget struct {
+	originType   OriginType
+	s3Bucket     awss3.IBucket
+	apiOrigin    awscloudfront.IOrigin
+	loadBalancer awselasticloadbalancingv2.IApplicationLoadBalancer
+	albOrigin    *ALBOriginConfig
+}
+
+// defaultTarget construye el originTarget del DefaultBehavior a partir de las props.
+func defaultTarget(props CloudFrontPropertiesV2) originTarget {
+	return originTarget{
+		originType:   props.OriginType,
+		s3Bucket:     props.S3Bucket,
+		apiOrigin:    props.ApiOrigin,
+		loadBalancer: props.LoadBalancer,
+		albOrigin:    props.ALBOrigin,
+	}
+}
+
+// hasFailover indica si el origen es un origin group (solo GET/HEAD/OPTIONS).
+func (t originTarget) hasFailover() bool {
+	return t.originType == OriginTypeALB && t.albOrigin != nil && t.albOrigin.FailoverLoadBalancer != nil
+}
+
+// newOrigin crea el IOrigin correspondiente al tipo de origen.
+// Para S3 se crea un OAC dedicado con id "<id>-OAC".
+func newOrigin(scope constructs.Construct, id string, target originTarget) awscloudfront.IOrigin {
+	switch target.originType {
+	case OriginTypeS3:
+		if target.s3Bucket == nil {
+			panic(fmt.Sprintf("%s: un origen S3 requiere S3Bucket", id))
+		}
+		oac := awscloudfront.NewS3OriginAccessControl(scope, jsii.String(fmt.Sprintf("%s-OAC", id)), &awscloudfront.S3OriginAccessControlProps{
+			Description: jsii.String(fmt.Sprintf("OAC for %s", id)),
+		})
+		return awscloudfrontorigins.S3BucketOrigin_WithOriginAccessControl(
+			target.s3Bucket,
+			&awscloudfrontorigins.S3BucketOriginWithOACProps{
+				OriginAccessControl: oac,
+			},
+		)
+
+	case OriginTypeAPI:
+		if target.apiOrigin == nil {
+			panic(fmt.Sprintf("%s: un origen API requiere ApiOrigin", id))
+		}
+		return target.apiOrigin
+
+	case OriginTypeALB:
+		if target.loadBalancer == nil {
+			panic(fmt.Sprintf("%s: un origen ALB requiere LoadBalancer", id))
+		}
+		return newALBOriginWithFailover(target.loadBalancer, target.albOrigin)
+
+	default:
+		panic(fmt.Sprintf("%s: origen no soportado: %s", id, target.originType))
+	}
+}
+
+// newBehaviorOptions construye las opciones de un behavior con los valores por
+// defecto del tipo de origen. Es el único punto donde se definen esos defaults,
+// compartido por el DefaultBehavior de cada Strategy y los behaviors adicionales.
+func newBehaviorOptions(target originTarget) *awscloudfront.AddBehaviorOptions {
+	switch target.originType {
+	case OriginTypeS3:
+		// Contenido estático: cache agresivo y security headers
+		return &awscloudfront.AddBehaviorOptions{
+			ViewerProtocolPolicy:  awscloudfront.ViewerProtocolPolicy_REDIRECT_TO_HTTPS,
+			AllowedMethods:        awscloudfront.AllowedMethods_ALLOW_GET_HEAD_OPTIONS(),
+			CachedMethods:         awscloudfront.CachedMethods_CACHE_GET_HEAD_OPTIONS(),
+			CachePolicy:           awscloudfront.CachePolicy_CACHING_OPTIMIZED(),
+			ResponseHeadersPolicy: awscloudfront.ResponseHeadersPolicy_SECURITY_HEADERS(),
+			Compress:              jsii.Bool(true),
+		}
+
+	case OriginTypeAPI:
+		// APIs: sin cache, todos los headers excepto Host, todos los métodos
+		return &awscloudfront.AddBehaviorOptions{
+			ViewerProtocolPolicy: awscloudfront.ViewerProtocolPolicy_HTTPS_ONLY,
+			AllowedMethods:       awscloudfront.AllowedMethods_ALLOW_ALL(),
+			CachedMethods:        awscloudfront.CachedMethods_CACHE_GET_HEAD(),
+			CachePolicy:          awscloudfront.CachePolicy_CACHING_DISABLED(),
+			OriginRequestPolicy:  awscloudfront.OriginRequestPolicy_ALL_VIEWER_EXCEPT_HOST_HEADER(),
+			Compress:             jsii.Bool(true),
+		}
+
+	case OriginTypeALB:
+		// Aplicaciones dinámicas: sin cache, todos los headers (incluido Host)
+		allowedMethods := awscloudfront.AllowedMethods_ALLOW_ALL()
+		if target.hasFailover() {
+			// Los origin groups no admiten métodos que modifican datos
+			allowedMethods = awscloudfront.AllowedMethods_ALLOW_GET_HEAD_OPTIONS()
+		}
+		return &awscloudfront.AddBehaviorOptions{
+			ViewerProtocolPolicy: awscloudfront.ViewerProtocolPolicy_REDIRECT_TO_HTTPS,
+			AllowedMethods:       allowedMethods,
+			CachedMethods:        awscloudfront.CachedMethods_CACHE_GET_HEAD(),
+			CachePolicy:          awscloudfront.CachePolicy_CACHING_DISABLED(),
+			OriginRequestPolicy:  awscloudfront.OriginRequestPolicy_ALL_VIEWER(),
+			Compress:             jsii.Bool(true),
+		}
+
+	default:
+		panic(fmt.Sprintf("Origen no soportado: %s", target.originType))
+	}
+}
+
+// newDefaultBehavior crea el origen y el DefaultBehavior de la distribución,
+// aplicando las políticas personalizadas y las funciones edge de las props.
+func newDefaultBehavior(scope constructs.Construct, id string, props CloudFrontPropertiesV2, shared sharedBehaviorConfig) *awscloudfront.BehaviorOptions {
+	target := defaultTarget(props)
+	origin := newOrigin(scope, id, target)
+
+	options := newBehaviorOptions(target)
+	applyPolicyOptions(scope, id, options, policyOverrides{
+		cachePolicy:           props.CachePolicyOptions,
+		originRequestPolicy:   props.OriginRequestPolicyOptions,
+		responseHeadersPolicy: props.ResponseHeadersPolicyOptions,
+	})
+	applyEdgeFunctions(scope, id, options, siteModeEdgeFunctions(props))
+	shared.apply(id, options, props.SignedAccess != nil && props.SignedAccess.ProtectDefaultBehavior)
+
+	return toBehaviorOptions(origin, options)
+}
+
+// toBehaviorOptions convierte AddBehaviorOptions en BehaviorOptions para el DefaultBehavior.
+func toBehaviorOptions(origin awscloudfront.IOrigin, options *awscloudfront.AddBehaviorOptions) *awscloudfront.BehaviorOptions {
+	return &awscloudfront.BehaviorOptions{
+		Origin:                origin,
+		ViewerProtocolPolicy:  options.ViewerProtocolPolicy,
+		AllowedMethods:        options.AllowedMethods,
+		CachedMethods:         options.CachedMethods,
+		CachePolicy:           options.CachePolicy,
+		OriginRequestPolicy:   options.OriginRequestPolicy,
+		ResponseHeadersPolicy: options.ResponseHeadersPolicy,
+		Compress:              options.Compress,
+		FunctionAssociations:  options.FunctionAssociations,
+		EdgeLambdas:           options.EdgeLambdas,
+		RealtimeLogConfig:     options.RealtimeLogConfig,
+		TrustedKeyGroups:      options.TrustedKeyGroups,
+	}
+}
+
+// addAdditionalBehaviors agrega los behaviors de props.AdditionalBehaviors en el
+// orden declarado (CloudFront evalúa los path patterns en ese orden).
+func addAdditionalBehaviors(scope constructs.Construct, id string, distribution awscloudfront.Distribution, props CloudFrontPropertiesV2, shared sharedBehaviorConfig) {
+	// Buckets que ya tienen la política OAC para esta distribución (evita Sids duplicados)
+	grantedBuckets := map[string]bool{}
+	if props.OriginType == OriginTypeS3 && props.S3Bucket != nil {
+		grantedBuckets[*props.S3Bucket.Node().Path()] = true
+	}
+
+	for i, behavior := range props.AdditionalBehaviors {
+		if behavior.PathPattern == "" {
+			panic(fmt.Sprintf("AdditionalBehaviors[%d]: PathPattern es obligatorio", i))
+		}
+
+		behaviorId := fmt.Sprintf("%s-Behavior%d", id, i)
+		target := originTarget{
+			originType:   behavior.OriginType,
+			s3Bucket:     behavior.S3Bucket,
+			apiOrigin:    behavior.ApiOrigin,
+			loadBalancer: behavior.LoadBalancer,
+			albOrigin:    behavior.ALBOrigin,
+		}
+
+		origin := newOrigin(scope, behaviorId, target)
+		options := newBehaviorOptions(target)
+
+		// Overrides por behavior
+		if behavior.CachePolicy != nil {
+			options.CachePolicy = behavior.CachePolicy
+		}
+		if behavior.OriginRequestPolicy != nil {
+			options.OriginRequestPolicy = behavior.OriginRequestPolicy
+		}
+		if behavior.AllowedMethods != nil {
+			options.AllowedMethods = behavior.AllowedMethods
+		}
+		if behavior.ViewerProtocolPolicy != "" {
+			options.ViewerProtocolPolicy = behavior.ViewerProtocolPolicy
+		}
+		applyPolicyOptions(scope, behaviorId, options, policyOverrides{
+			cachePolicy:           behavior.CachePolicyOptions,
+			originRequestPolicy:   behavior.OriginRequestPolicyOptions,
+			responseHeadersPolicy: behavior.ResponseHeadersPolicyOptions,
+		})
+		applyEdgeFunctions(scope, behaviorId, options, behavior.EdgeFunctions)
+		shared.apply(behaviorId, options, behavior.RequireSignedAccess)
+
+		distribution.AddBehavior(jsii.String(behavior.PathPattern), origin, options)
+
+		if target.originType == OriginTypeS3 && props.AutoConfigureS3BucketPolicy {
+			bucketPath := *behavior.S3Bucket.Node().Path()
+			if !grantedBuckets[bucketPath] {
+				grantCloudFrontRead(behavior.S3Bucket, distribution)
+				grantedBuckets[bucketPath] = true
+			}
+		}
+	}
+}
+
+// grantCloudFrontRead permite al service principal de CloudFront leer objetos del
+// bucket únicamente desde esta distribución (condición AWS:SourceArn).
+func grantCloudFrontRead(bucket awss3.IBucket, distribution awscloudfront.Distribution) {
+	bucket.AddToResourcePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Sid:    jsii.String("AllowCloudFrontServicePrincipal"),
+		Effect: awsiam.Effect_ALLOW,
+		Principals: &[]awsiam.IPrincipal{
+			awsiam.NewServicePrincipal(jsii.String("cloudfront.amazonaws.com"), nil),
+		},
+		Actions: jsii.Strings("s3:GetObject"),
+		Resources: jsii.Strings(
+			fmt.Sprintf("%s/*", *bucket.BucketArn()),
+		),
+		Conditions: &map[string]interface{}{
+			"StringEquals": map[string]interface{}{
+				"AWS:SourceArn": *distribution.DistributionArn(),
+			},
+		},
+	}))
+}
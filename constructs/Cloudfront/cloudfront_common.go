@@ -0,0 +1,68 @@
+package cloudfront
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscertificatemanager"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfront"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// -----------------------------------------------------------------------------
+// Helpers compartidos por todos los Strategies de CloudFront
+// -----------------------------------------------------------------------------
+
+// newDistributionProps crea las DistributionProps base comunes a todos los Strategies.
+func newDistributionProps(props CloudFrontPropertiesV2) *awscloudfront.DistributionProps {
+	priceClass := props.PriceClass
+	if priceClass == "" {
+		priceClass = awscloudfront.PriceClass_PRICE_CLASS_100
+	}
+
+	return &awscloudfront.DistributionProps{
+		Comment:     jsii.String(props.Comment),
+		HttpVersion: awscloudfront.HttpVersion_HTTP2_AND_3,
+		EnableIpv6:  jsii.Bool(true),
+		PriceClass:  priceClass,
+	}
+}
+
+// applyCustomDomain configura certificado ACM y dominios alternativos (CNAMEs)
+// sobre las DistributionProps, si fueron proporcionados. Con HostedZone y sin
+// CertificateArn se emite un certificado validado por DNS.
+func applyCustomDomain(scope constructs.Construct, id string, props CloudFrontPropertiesV2, distributionProps *awscloudfront.DistributionProps) {
+	// SSL/TLS (opcional)
+	var cert awscertificatemanager.ICertificate
+	if props.CertificateArn != "" {
+		cert = awscertificatemanager.Certificate_FromCertificateArn(
+			scope,
+			jsii.String(fmt.Sprintf("%s-Cert", id)),
+			jsii.String(props.CertificateArn),
+		)
+	} else if props.HostedZone != nil {
+		cert = newDnsValidatedCertificate(scope, id, props)
+	}
+
+	if cert != nil {
+		distributionProps.Certificate = cert
+		distributionProps.MinimumProtocolVersion = awscloudfront.SecurityPolicyProtocol_TLS_V1_2_2021
+		distributionProps.SslSupportMethod = awscloudfront.SSLMethod_SNI
+	}
+
+	// Dominio personalizado (opcional)
+	if len(props.DomainNames) > 0 {
+		var domains []*string
+		for _, d := range props.DomainNames {
+			domains = append(domains, jsii.String(d))
+		}
+		distributionProps.DomainNames = &domains
+	}
+}
+
+// applyWebAcl asocia el WebACL de WAF (scope CLOUDFRONT) a la distribución, si aplica.
+func applyWebAcl(props CloudFrontPropertiesV2, distributionProps *awscloudfront.DistributionProps) {
+	if props.WebAclArn != "" {
+		distributionProps.WebAclId = jsii.String(props.WebAclArn)
+	}
+}
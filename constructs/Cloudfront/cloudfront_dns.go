@@ -0,0 +1,108 @@
+package cloudfront
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscertificatemanager"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfront"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsroute53"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsroute53targets"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// -----------------------------------------------------------------------------
+// Route53 + ACM: certificado DNS-validated y registros alias por dominio
+// -----------------------------------------------------------------------------
+
+// certificateRegion es la única región desde la que CloudFront acepta certificados.
+const certificateRegion = "us-east-1"
+
+// newDnsValidatedCertificate crea un certificado ACM validado por DNS en la
+// hosted zone para todos los DomainNames.
+//
+// Si el stack no está en us-east-1 el certificado se crea en un stack auxiliar
+// "<stack>-<id>-Certificate" en us-east-1 y se referencia con CrossRegionReferences.
+// En ese caso el stack que contiene la distribución debe declarar
+// CrossRegionReferences: true y una región explícita en su Env, y la hosted
+// zone debe provenir de HostedZone_FromLookup o FromHostedZoneAttributes.
+func newDnsValidatedCertificate(scope constructs.Construct, id string, props CloudFrontPropertiesV2) awscertificatemanager.ICertificate {
+	if len(props.DomainNames) == 0 {
+		panic("HostedZone requiere al menos un dominio en props.DomainNames para emitir el certificado")
+	}
+
+	stack := awscdk.Stack_Of(scope)
+	region := stack.Region()
+	if awscdk.Token_IsUnresolved(region) {
+		panic("El certificado para CloudFront requiere un stack con región explícita (StackProps.Env.Region) " +
+			"o un CertificateArn existente en us-east-1")
+	}
+
+	certScope := scope
+	zone := props.HostedZone
+	if *region != certificateRegion {
+		certStack := awscdk.NewStack(stack.Node().Scope(), jsii.String(fmt.Sprintf("%s-%s-Certificate", *stack.Node().Id(), id)), &awscdk.StackProps{
+			Env: &awscdk.Environment{
+				Account: stack.Account(),
+				Region:  jsii.String(certificateRegion),
+			},
+			CrossRegionReferences: jsii.Bool(true),
+			Description:           jsii.String(fmt.Sprintf("ACM certificate (us-east-1) for CloudFront distribution %s", id)),
+		})
+		stack.AddDependency(certStack, jsii.String("CloudFront certificate must exist in us-east-1"))
+
+		certScope = certStack
+		zone = awsroute53.HostedZone_FromHostedZoneAttributes(certStack, jsii.String(fmt.Sprintf("%s-Zone", id)), &awsroute53.HostedZoneAttributes{
+			HostedZoneId: props.HostedZone.HostedZoneId(),
+			ZoneName:     props.HostedZone.ZoneName(),
+		})
+	}
+
+	var alternativeNames *[]*string
+	if len(props.DomainNames) > 1 {
+		alternativeNames = jsii.Strings(props.DomainNames[1:]...)
+	}
+
+	return awscertificatemanager.NewCertificate(certScope, jsii.String(fmt.Sprintf("%s-Certificate", id)), &awscertificatemanager.CertificateProps{
+		DomainName:              jsii.String(props.DomainNames[0]),
+		SubjectAlternativeNames: alternativeNames,
+		Validation:              awscertificatemanager.CertificateValidation_FromDns(zone),
+	})
+}
+
+// addAliasRecords crea registros A y AAAA (alias a la distribución) en la
+// hosted zone para cada dominio de props.DomainNames.
+func addAliasRecords(scope constructs.Construct, id string, props CloudFrontPropertiesV2, distribution awscloudfront.Distribution) {
+	if props.HostedZone == nil {
+		return
+	}
+
+	zoneName := props.HostedZone.ZoneName()
+	target := awsroute53.RecordTarget_FromAlias(awsroute53targets.NewCloudFrontTarget(distribution))
+
+	for i, domain := range props.DomainNames {
+		if !awscdk.Token_IsUnresolved(zoneName) && !inZone(domain, *zoneName) {
+			panic(fmt.Sprintf("El dominio %s no pertenece a la hosted zone %s", domain, *zoneName))
+		}
+
+		awsroute53.NewARecord(scope, jsii.String(fmt.Sprintf("%s-AliasA%d", id, i)), &awsroute53.ARecordProps{
+			Zone:       props.HostedZone,
+			RecordName: jsii.String(domain),
+			Target:     target,
+		})
+		awsroute53.NewAaaaRecord(scope, jsii.String(fmt.Sprintf("%s-AliasAAAA%d", id, i)), &awsroute53.AaaaRecordProps{
+			Zone:       props.HostedZone,
+			RecordName: jsii.String(domain),
+			Target:     target,
+		})
+	}
+}
+
+// inZone indica si el dominio es el apex de la zona o un subdominio de ella.
+func inZone(domain, zoneName string) bool {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	zoneName = strings.TrimSuffix(strings.ToLower(zoneName), ".")
+	return domain == zoneName || strings.HasSuffix(domain, "."+zoneName)
+}
@@ -0,0 +1,158 @@
+package cloudfront
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfront"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awslambda"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// -----------------------------------------------------------------------------
+// ENUM: Eventos en los que se puede ejecutar una función edge
+// -----------------------------------------------------------------------------
+type EdgeEventType string
+
+const (
+	EdgeEventViewerRequest  EdgeEventType = "viewer-request"
+	EdgeEventViewerResponse EdgeEventType = "viewer-response"
+
+	// Solo Lambda@Edge (las CloudFront Functions no se ejecutan en eventos de origen)
+	EdgeEventOriginRequest  EdgeEventType = "origin-request"
+	EdgeEventOriginResponse EdgeEventType = "origin-response"
+)
+
+// EdgeFunction define una función que se ejecuta en el edge para un behavior.
+//
+// Debe indicarse exactamente una fuente:
+//   - Code: código JavaScript inline (CloudFront Function)
+//   - FilePath: ruta a un archivo .js (CloudFront Function)
+//   - LambdaVersion: versión publicada de una Lambda@Edge (us-east-1)
+//
+// CloudFront admite una sola función por event type en cada behavior.
+type EdgeFunction struct {
+	EventType EdgeEventType
+
+	// Fuente de la función (solo una)
+	Code          string
+	FilePath      string
+	LambdaVersion awslambda.IVersion
+
+	// Runtime de la CloudFront Function. Por defecto: cloudfront-js-2.0
+	Runtime awscloudfront.FunctionRuntime
+
+	// Expone el body del request a la Lambda@Edge (solo viewer/origin-request)
+	IncludeBody bool
+}
+
+// Equivalencias de EdgeEventType con los enums de CDK
+var functionEventTypes = map[EdgeEventType]awscloudfront.FunctionEventType{
+	EdgeEventViewerRequest:  awscloudfront.FunctionEventType_VIEWER_REQUEST,
+	EdgeEventViewerResponse: awscloudfront.FunctionEventType_VIEWER_RESPONSE,
+}
+
+var lambdaEdgeEventTypes = map[EdgeEventType]awscloudfront.LambdaEdgeEventType{
+	EdgeEventViewerRequest:  awscloudfront.LambdaEdgeEventType_VIEWER_REQUEST,
+	EdgeEventViewerResponse: awscloudfront.LambdaEdgeEventType_VIEWER_RESPONSE,
+	EdgeEventOriginRequest:  awscloudfront.LambdaEdgeEventType_ORIGIN_REQUEST,
+	EdgeEventOriginResponse: awscloudfront.LambdaEdgeEventType_ORIGIN_RESPONSE,
+}
+
+var functionIdSuffixes = map[EdgeEventType]string{
+	EdgeEventViewerRequest:  "ViewerRequest",
+	EdgeEventViewerResponse: "ViewerResponse",
+}
+
+// isLambda indica si la función es una Lambda@Edge.
+func (f EdgeFunction) isLambda() bool {
+	return f.LambdaVersion != nil
+}
+
+// applyEdgeFunctions crea las CloudFront Functions y asocia las funciones edge
+// a las opciones del behavior. Los ids de las funciones son "<id>-ViewerRequestFunction"
+// y "<id>-ViewerResponseFunction".
+func applyEdgeFunctions(scope constructs.Construct, id string, options *awscloudfront.AddBehaviorOptions, functions []EdgeFunction) {
+	if len(functions) == 0 {
+		return
+	}
+
+	validateEdgeFunctions(id, functions)
+
+	var functionAssociations []*awscloudfront.FunctionAssociation
+	var edgeLambdas []*awscloudfront.EdgeLambda
+
+	for _, f := range functions {
+		if f.isLambda() {
+			edgeLambdas = append(edgeLambdas, &awscloudfront.EdgeLambda{
+				FunctionVersion: f.LambdaVersion,
+				EventType:       lambdaEdgeEventTypes[f.EventType],
+				IncludeBody:     jsii.Bool(f.IncludeBody),
+			})
+			continue
+		}
+
+		code := awscloudfront.FunctionCode_FromInline(jsii.String(f.Code))
+		if f.FilePath != "" {
+			code = awscloudfront.FunctionCode_FromFile(&awscloudfront.FileCodeOptions{
+				FilePath: jsii.String(f.FilePath),
+			})
+		}
+
+		runtime := f.Runtime
+		if runtime == nil {
+			runtime = awscloudfront.FunctionRuntime_JS_2_0()
+		}
+
+		function := awscloudfront.NewFunction(scope, jsii.String(fmt.Sprintf("%s-%sFunction", id, functionIdSuffixes[f.EventType])), &awscloudfront.FunctionProps{
+			Code:    code,
+			Runtime: runtime,
+			Comment: jsii.String(fmt.Sprintf("%s function for %s", f.EventType, id)),
+		})
+
+		functionAssociations = append(functionAssociations, &awscloudfront.FunctionAssociation{
+			Function:  function,
+			EventType: functionEventTypes[f.EventType],
+		})
+	}
+
+	if len(functionAssociations) > 0 {
+		options.FunctionAssociations = &functionAssociations
+	}
+	if len(edgeLambdas) > 0 {
+		options.EdgeLambdas = &edgeLambdas
+	}
+}
+
+// validateEdgeFunctions verifica fuente única, event type válido y que no haya
+// dos funciones (CloudFront Function o Lambda@Edge) en el mismo event type.
+func validateEdgeFunctions(id string, functions []EdgeFunction) {
+	seen := map[EdgeEventType]bool{}
+
+	for i, f := range functions {
+		sources := 0
+		for _, set := range []bool{f.Code != "", f.FilePath != "", f.LambdaVersion != nil} {
+			if set {
+				sources++
+			}
+		}
+		if sources != 1 {
+			panic(fmt.Sprintf("%s: EdgeFunctions[%d] debe definir exactamente una fuente (Code, FilePath o LambdaVersion)", id, i))
+		}
+
+		switch f.EventType {
+		case EdgeEventViewerRequest, EdgeEventViewerResponse:
+		case EdgeEventOriginRequest, EdgeEventOriginResponse:
+			if !f.isLambda() {
+				panic(fmt.Sprintf("%s: EdgeFunctions[%d]: las CloudFront Functions solo soportan viewer-request y viewer-response", id, i))
+			}
+		default:
+			panic(fmt.Sprintf("%s: EdgeFunctions[%d]: EventType no soportado: %q", id, i, f.EventType))
+		}
+
+		if seen[f.EventType] {
+			panic(fmt.Sprintf("%s: solo se permite una función %s por behavior", id, f.EventType))
+		}
+		seen[f.EventType] = true
+	}
+}
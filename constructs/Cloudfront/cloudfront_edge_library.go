@@ -0,0 +1,169 @@
+package cloudfront
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// Funciones edge listas para usar (CloudFront Functions, runtime JS 2.0)
+// -----------------------------------------------------------------------------
+
+// RedirectsFunction redirige paths exactos a otra URL (viewer-request).
+// Ej: {"/blog": "/news/", "/old-page.html": "https://example.com/new"}
+// permanent=true responde 301, false responde 302.
+func RedirectsFunction(redirects map[string]string, permanent bool) EdgeFunction {
+	if len(redirects) == 0 {
+		panic("RedirectsFunction requiere al menos una redirección")
+	}
+
+	// Orden determinístico para que el template no cambie entre synths
+	sources := make([]string, 0, len(redirects))
+	for source := range redirects {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	var entries []string
+	for _, source := range sources {
+		entries = append(entries, fmt.Sprintf("    %s: %s", jsString(source), jsString(redirects[source])))
+	}
+
+	statusCode, statusDescription := 302, "Found"
+	if permanent {
+		statusCode, statusDescription = 301, "Moved Permanently"
+	}
+
+	return EdgeFunction{
+		EventType: EdgeEventViewerRequest,
+		Code: fmt.Sprintf(`var redirects = {
+%s
+};
+
+function handler(event) {
+    var request = event.request;
+    var target = redirects[request.uri];
+
+    if (target) {
+        return {
+            statusCode: %d,
+            statusDescription: '%s',
+            headers: { location: { value: target } }
+        };
+    }
+
+    return request;
+}`, strings.Join(entries, ",\n"), statusCode, statusDescription),
+	}
+}
+
+// BasicAuthFunction protege el behavior con HTTP Basic Auth (viewer-request).
+// Pensado para entornos de staging: las credenciales quedan en el código de la
+// función (visible en el template), no usar para proteger datos sensibles.
+func BasicAuthFunction(username, password string) EdgeFunction {
+	if username == "" || password == "" {
+		panic("BasicAuthFunction requiere username y password")
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+
+	return EdgeFunction{
+		EventType: EdgeEventViewerRequest,
+		Code: fmt.Sprintf(`var expected = %s;
+
+function handler(event) {
+    var request = event.request;
+    var authorization = request.headers.authorization;
+
+    if (!authorization || authorization.value !== expected) {
+        return {
+            statusCode: 401,
+            statusDescription: 'Unauthorized',
+            headers: { 'www-authenticate': { value: 'Basic realm="Restricted"' } }
+        };
+    }
+
+    return request;
+}`, jsString("Basic "+credentials)),
+	}
+}
+
+// SecurityHeadersFunction agrega security headers a la respuesta (viewer-response):
+// HSTS (2 años, includeSubDomains, preload), X-Content-Type-Options, X-Frame-Options,
+// Referrer-Policy y Permissions-Policy. contentSecurityPolicy es opcional.
+func SecurityHeadersFunction(contentSecurityPolicy string) EdgeFunction {
+	csp := ""
+	if contentSecurityPolicy != "" {
+		csp = fmt.Sprintf("\n    headers['content-security-policy'] = { value: %s };", jsString(contentSecurityPolicy))
+	}
+
+	return EdgeFunction{
+		EventType: EdgeEventViewerResponse,
+		Code: fmt.Sprintf(`function handler(event) {
+    var response = event.response;
+    var headers = response.headers;
+
+    headers['strict-transport-security'] = { value: 'max-age=63072000; includeSubDomains; preload' };
+    headers['x-content-type-options'] = { value: 'nosniff' };
+    headers['x-frame-options'] = { value: 'DENY' };
+    headers['referrer-policy'] = { value: 'strict-origin-when-cross-origin' };
+    headers['permissions-policy'] = { value: 'camera=(), microphone=(), geolocation=()' };%s
+
+    return response;
+}`, csp),
+	}
+}
+
+// URLNormalizationFunction redirige (301) a la URL canónica (viewer-request):
+// colapsa barras duplicadas ("//a///b" -> "/a/b"), quita el "index.html" final
+// ("/docs/index.html" -> "/docs/") y, si lowercase=true, pasa el path a minúsculas.
+// Conserva el query string.
+func URLNormalizationFunction(lowercase bool) EdgeFunction {
+	lower := ""
+	if lowercase {
+		lower = ".toLowerCase()"
+	}
+
+	return EdgeFunction{
+		EventType: EdgeEventViewerRequest,
+		Code: fmt.Sprintf(`function handler(event) {
+    var request = event.request;
+    var uri = request.uri;
+    var normalized = uri.replace(/\/{2,}/g, '/')%s;
+
+    if (normalized.endsWith('/index.html')) {
+        normalized = normalized.slice(0, -'index.html'.length);
+    }
+
+    if (normalized === uri) {
+        return request;
+    }
+
+    var query = Object.keys(request.querystring).map(function (key) {
+        var param = request.querystring[key];
+        var values = param.multiValue ? param.multiValue : [param];
+        return values.map(function (v) {
+            return v.value ? key + '=' + v.value : key;
+        }).join('&');
+    }).join('&');
+
+    return {
+        statusCode: 301,
+        statusDescription: 'Moved Permanently',
+        headers: { location: { value: query ? normalized + '?' + query : normalized } }
+    };
+}`, lower),
+	}
+}
+
+// jsString serializa un string Go como literal JavaScript (escapando comillas, etc.).
+func jsString(value string) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Sprintf("no se pudo serializar %q: %v", value, err))
+	}
+	return string(encoded)
+}
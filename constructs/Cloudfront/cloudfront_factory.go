@@ -3,8 +3,9 @@ package cloudfront
 import (
 	"fmt"
 
-	"github.com/aws/aws-cdk-go/awscdk/v2"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfront"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awselasticloadbalancingv2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsroute53"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awss3"
 	"github.com/aws/constructs-go/constructs/v10"
 )
@@ -29,7 +30,10 @@ type CloudFrontPropertiesV2 struct {
 	// Recursos posibles (solo uno debe estar presente según el tipo)
 	S3Bucket     awss3.IBucket
 	ApiOrigin    awscloudfront.IOrigin
-	LoadBalancer awscdk.Resource
+	LoadBalancer awselasticloadbalancingv2.IApplicationLoadBalancer
+
+	// Configuración específica del origen ALB (opcional, solo OriginTypeALB)
+	ALBOrigin *ALBOriginConfig
 
 	// Configuración opcional
 	DomainNames                 []string
@@ -38,6 +42,55 @@ type CloudFrontPropertiesV2 struct {
 	Comment                     string
 	EnableAccessLogging         bool
 	AutoConfigureS3BucketPolicy bool
+
+	// Hosted zone de los DomainNames (opcional). Si se define:
+	//   - sin CertificateArn, se emite un certificado ACM validado por DNS
+	//     (en un stack auxiliar de us-east-1 si el stack está en otra región)
+	//   - se crean registros alias A/AAAA para cada dominio
+	HostedZone awsroute53.IHostedZone
+
+	// Standard logs (opcional). Implica EnableAccessLogging.
+	// Si es nil y EnableAccessLogging=true se crea un bucket de logs con valores por defecto.
+	AccessLogging *AccessLoggingOptions
+
+	// Real-time logs hacia Kinesis (opcional). Se aplican a todos los behaviors.
+	RealtimeLogging *RealtimeLoggingOptions
+
+	// Contenido privado con signed URLs/cookies (opcional)
+	SignedAccess *SignedAccessOptions
+
+	// Políticas personalizadas del DefaultBehavior (opcional).
+	// Si son nil se usan las managed policies del OriginType.
+	CachePolicyOptions           *CachePolicyOptions
+	OriginRequestPolicyOptions   *OriginRequestPolicyOptions
+	ResponseHeadersPolicyOptions *ResponseHeadersPolicyOptions
+
+	// Funciones edge del DefaultBehavior (opcional). Una por event type.
+	// Ej: []EdgeFunction{BasicAuthFunction("staging", pass), SecurityHeadersFunction("")}
+	EdgeFunctions []EdgeFunction
+
+	// Price class de la distribución. Por defecto: PRICE_CLASS_100
+	PriceClass awscloudfront.PriceClass
+
+	// Default root object (solo OriginTypeS3). Por defecto: "index.html"
+	DefaultRootObject string
+
+	// Modo de sitio (solo OriginTypeS3). Por defecto: SiteModeSPA
+	//   - SiteModeSPA: 403/404 -> /index.html con status 200
+	//   - SiteModeStatic: 403/404 -> NotFoundPagePath con status 404 + reescritura "/about/" -> "/about/index.html"
+	//   - SiteModeCustom: solo los mapeos de ErrorPages
+	SiteMode SiteMode
+
+	// Página 404 del modo estático. Por defecto: "/404.html"
+	NotFoundPagePath string
+
+	// Mapeos de errores para SiteModeCustom
+	ErrorPages []ErrorPageMapping
+
+	// Behaviors adicionales enrutados por path pattern (opcional).
+	// Se evalúan en el orden declarado, antes del DefaultBehavior.
+	// Ej: SPA en S3 como default + "/api/*" hacia un origen API.
+	AdditionalBehaviors []BehaviorConfig
 }
 
 // -----------------------------------------------------------------------------
@@ -58,14 +111,14 @@ func NewDistributionV2(scope constructs.Construct, id string, props CloudFrontPr
 		if props.ApiOrigin == nil {
 			panic("Debe proporcionar ApiOrigin para una distribución API")
 		}
-		//strategy = &APICloudFrontStrategy{}
-		panic("API strategy no implementada aún")
+		strategy = &APICloudFrontStrategy{}
+
 	case OriginTypeALB:
 		if props.LoadBalancer == nil {
 			panic("Debe proporcionar LoadBalancer para una distribución ALB")
 		}
-		//strategy = &ALBCloudFrontStrategy{}
-		panic("ALB strategy no implementada aún")
+		strategy = &ALBCloudFrontStrategy{}
+
 	default:
 		panic(fmt.Sprintf("Origen no soportado: %s", props.OriginType))
 	}
@@ -0,0 +1,189 @@
+package cloudfront
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfront"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awskinesis"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awskinesisfirehose"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awss3"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// -----------------------------------------------------------------------------
+// Logging de la distribución: standard logs (S3) y real-time logs (Kinesis)
+// -----------------------------------------------------------------------------
+
+// AccessLoggingOptions configura los standard logs de CloudFront.
+type AccessLoggingOptions struct {
+	// Bucket de logs existente (opcional). Debe tener ObjectOwnership
+	// OBJECT_WRITER o BUCKET_OWNER_PREFERRED (CloudFront escribe con ACLs).
+	// Si es nil se crea "<id>-LogBucket".
+	Bucket awss3.IBucket
+
+	// Prefijo de los logs. Por defecto: "<id>/" (uno por distribución)
+	Prefix string
+
+	// Días de retención de los logs (solo bucket creado). Por defecto: 90
+	RetentionDays float64
+
+	// Removal policy del bucket creado. Por defecto: RETAIN
+	RemovalPolicy awscdk.RemovalPolicy
+
+	// Incluye cookies en los logs. Por defecto: false
+	IncludeCookies bool
+}
+
+// RealtimeLoggingOptions configura los real-time logs de CloudFront hacia
+// Kinesis Data Streams, con entrega opcional a S3 mediante Firehose.
+type RealtimeLoggingOptions struct {
+	// Porcentaje de requests registrados (1-100). Por defecto: 100
+	SamplingRate float64
+
+	// Campos registrados. Por defecto: defaultRealtimeLogFields
+	Fields []string
+
+	// Stream de destino (opcional). Si es nil se crea "<id>-RealtimeLogStream"
+	// con 1 shard, cifrado administrado y retención de 24 horas.
+	Stream awskinesis.IStream
+
+	// Entrega los registros del stream a S3 mediante Firehose ("<id>-RealtimeLogDelivery").
+	// Destino: DeliveryBucket o, si es nil, el bucket de standard logs.
+	DeliverToS3    bool
+	DeliveryBucket awss3.IBucket
+}
+
+// defaultRealtimeLogFields son los campos registrados si no se especifican otros.
+var defaultRealtimeLogFields = []string{
+	"timestamp",
+	"c-ip",
+	"cs-method",
+	"cs-host",
+	"cs-uri-stem",
+	"sc-status",
+	"sc-bytes",
+	"time-taken",
+	"x-edge-location",
+	"x-edge-result-type",
+	"cs-user-agent",
+	"cs-referer",
+}
+
+// applyLogging configura los standard logs (bucket, prefijo, retención) sobre las
+// DistributionProps y devuelve la configuración de real-time logs (nil si no aplica).
+func applyLogging(scope constructs.Construct, id string, props CloudFrontPropertiesV2, distributionProps *awscloudfront.DistributionProps) awscloudfront.IRealtimeLogConfig {
+	var logBucket awss3.IBucket
+
+	// Standard logs
+	if props.EnableAccessLogging || props.AccessLogging != nil {
+		options := props.AccessLogging
+		if options == nil {
+			options = &AccessLoggingOptions{}
+		}
+
+		logBucket = options.Bucket
+		if logBucket == nil {
+			logBucket = newLogBucket(scope, id, options)
+		}
+
+		prefix := options.Prefix
+		if prefix == "" {
+			prefix = id + "/"
+		}
+
+		distributionProps.EnableLogging = jsii.Bool(true)
+		distributionProps.LogBucket = logBucket
+		distributionProps.LogFilePrefix = jsii.String(prefix)
+		distributionProps.LogIncludesCookies = jsii.Bool(options.IncludeCookies)
+	}
+
+	// Real-time logs
+	if props.RealtimeLogging == nil {
+		return nil
+	}
+	return newRealtimeLogConfig(scope, id, props.RealtimeLogging, logBucket)
+}
+
+// newLogBucket crea el bucket de standard logs con ObjectOwnership OBJECT_WRITER
+// (CloudFront entrega los logs con ACLs), cifrado S3 y expiración por lifecycle.
+func newLogBucket(scope constructs.Construct, id string, options *AccessLoggingOptions) awss3.IBucket {
+	retentionDays := options.RetentionDays
+	if retentionDays == 0 {
+		retentionDays = 90
+	}
+
+	removalPolicy := options.RemovalPolicy
+	if removalPolicy == "" {
+		removalPolicy = awscdk.RemovalPolicy_RETAIN
+	}
+
+	return awss3.NewBucket(scope, jsii.String(fmt.Sprintf("%s-LogBucket", id)), &awss3.BucketProps{
+		ObjectOwnership:   awss3.ObjectOwnership_OBJECT_WRITER,
+		BlockPublicAccess: awss3.BlockPublicAccess_BLOCK_ALL(),
+		Encryption:        awss3.BucketEncryption_S3_MANAGED,
+		EnforceSSL:        jsii.Bool(true),
+		RemovalPolicy:     removalPolicy,
+		AutoDeleteObjects: jsii.Bool(removalPolicy == awscdk.RemovalPolicy_DESTROY),
+		LifecycleRules: &[]*awss3.LifecycleRule{
+			{
+				Id:         jsii.String("ExpireAccessLogs"),
+				Enabled:    jsii.Bool(true),
+				Expiration: awscdk.Duration_Days(jsii.Number(retentionDays)),
+			},
+		},
+	})
+}
+
+// newRealtimeLogConfig crea (o reutiliza) el stream de Kinesis, la configuración
+// de real-time logs y, opcionalmente, el delivery stream de Firehose hacia S3.
+func newRealtimeLogConfig(scope constructs.Construct, id string, options *RealtimeLoggingOptions, logBucket awss3.IBucket) awscloudfront.IRealtimeLogConfig {
+	samplingRate := options.SamplingRate
+	if samplingRate == 0 {
+		samplingRate = 100
+	}
+	if samplingRate < 1 || samplingRate > 100 {
+		panic(fmt.Sprintf("RealtimeLogging.SamplingRate debe estar entre 1 y 100 (recibido: %v)", samplingRate))
+	}
+
+	fields := options.Fields
+	if len(fields) == 0 {
+		fields = defaultRealtimeLogFields
+	}
+
+	stream := options.Stream
+	if stream == nil {
+		stream = awskinesis.NewStream(scope, jsii.String(fmt.Sprintf("%s-RealtimeLogStream", id)), &awskinesis.StreamProps{
+			ShardCount:      jsii.Number(1),
+			Encryption:      awskinesis.StreamEncryption_MANAGED,
+			RetentionPeriod: awscdk.Duration_Hours(jsii.Number(24)),
+		})
+	}
+
+	if options.DeliverToS3 {
+		deliveryBucket := options.DeliveryBucket
+		if deliveryBucket == nil {
+			deliveryBucket = logBucket
+		}
+		if deliveryBucket == nil {
+			panic("RealtimeLogging.DeliverToS3 requiere DeliveryBucket o EnableAccessLogging")
+		}
+
+		awskinesisfirehose.NewDeliveryStream(scope, jsii.String(fmt.Sprintf("%s-RealtimeLogDelivery", id)), &awskinesisfirehose.DeliveryStreamProps{
+			Source: awskinesisfirehose.NewKinesisStreamSource(stream),
+			Destination: awskinesisfirehose.NewS3Bucket(deliveryBucket, &awskinesisfirehose.S3BucketProps{
+				DataOutputPrefix:  jsii.String(fmt.Sprintf("realtime/%s/", id)),
+				ErrorOutputPrefix: jsii.String(fmt.Sprintf("realtime-errors/%s/", id)),
+			}),
+		})
+	}
+
+	return awscloudfront.NewRealtimeLogConfig(scope, jsii.String(fmt.Sprintf("%s-RealtimeLogConfig", id)), &awscloudfront.RealtimeLogConfigProps{
+		EndPoints: &[]awscloudfront.Endpoint{
+			awscloudfront.Endpoint_FromKinesisStream(stream, nil),
+		},
+		Fields:       jsii.Strings(fields...),
+		SamplingRate: jsii.Number(samplingRate),
+	})
+}
@@ -0,0 +1,323 @@
+package cloudfront
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfront"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// -----------------------------------------------------------------------------
+// Opciones tipadas para políticas de cache, origin request y response headers
+// -----------------------------------------------------------------------------
+
+// CachePolicyOptions define una cache policy personalizada.
+// Reemplaza la managed policy del OriginType (ej. CACHING_OPTIMIZED en S3).
+type CachePolicyOptions struct {
+	// Nombre de la policy (opcional, CDK genera uno único si está vacío)
+	Name string
+
+	// TTLs (opcionales). Defaults de CloudFront: default 1 día, min 0s, max 1 año
+	DefaultTTL awscdk.Duration
+	MinTTL     awscdk.Duration
+	MaxTTL     awscdk.Duration
+
+	// Query strings incluidos en la cache key.
+	// AllQueryStrings tiene prioridad sobre QueryStringAllowList. Por defecto: ninguno
+	AllQueryStrings      bool
+	QueryStringAllowList []string
+
+	// Cookies incluidas en la cache key. Por defecto: ninguna
+	CookieAllowList []string
+
+	// Headers incluidos en la cache key. Por defecto: ninguno
+	HeaderAllowList []string
+}
+
+// OriginRequestPolicyOptions define qué valores del viewer se reenvían al origen
+// sin formar parte de la cache key.
+type OriginRequestPolicyOptions struct {
+	// Nombre de la policy (opcional)
+	Name string
+
+	// Query strings reenviados al origen. Por defecto: ninguno
+	AllQueryStrings      bool
+	QueryStringAllowList []string
+
+	// Cookies reenviadas al origen. Por defecto: ninguna
+	AllCookies      bool
+	CookieAllowList []string
+
+	// Headers reenviados al origen. Por defecto: ninguno
+	AllViewerHeaders bool
+	HeaderAllowList  []string
+}
+
+// ResponseHeadersPolicyOptions define una response headers policy personalizada.
+// Los security headers base (X-Content-Type-Options, X-Frame-Options,
+// Referrer-Policy, X-XSS-Protection y HSTS) siempre se incluyen.
+type ResponseHeadersPolicyOptions struct {
+	// Nombre de la policy (opcional)
+	Name string
+
+	// Content-Security-Policy (opcional). Ej: "default-src 'self'; img-src 'self' data:"
+	ContentSecurityPolicy string
+
+	// Strict-Transport-Security. HSTSMaxAge por defecto: 365 días
+	HSTSMaxAge            awscdk.Duration
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+
+	// X-Frame-Options. Por defecto: DENY
+	FrameOption awscloudfront.HeadersFrameOption
+
+	// Referrer-Policy. Por defecto: strict-origin-when-cross-origin
+	ReferrerPolicy awscloudfront.HeadersReferrerPolicy
+
+	// CORS (opcional)
+	CORS *CORSOptions
+
+	// Headers personalizados agregados a cada respuesta (opcional)
+	CustomHeaders map[string]string
+
+	// Headers eliminados de la respuesta del origen (opcional). Ej: "Server"
+	RemoveHeaders []string
+}
+
+// CORSOptions define los headers Access-Control-* de la response headers policy.
+type CORSOptions struct {
+	// Orígenes permitidos (obligatorio). Ej: "https://app.example.com"
+	AllowOrigins []string
+
+	// Métodos permitidos. Por defecto: GET, HEAD, OPTIONS
+	AllowMethods []string
+
+	// Headers permitidos. Por defecto: "*"
+	AllowHeaders []string
+
+	// Headers expuestos al navegador (opcional)
+	ExposeHeaders []string
+
+	// Access-Control-Allow-Credentials. Por defecto: false
+	AllowCredentials bool
+
+	// Access-Control-Max-Age (opcional)
+	MaxAge awscdk.Duration
+}
+
+// policyOverrides agrupa las opciones de políticas de un behavior.
+type policyOverrides struct {
+	cachePolicy           *CachePolicyOptions
+	originRequestPolicy   *OriginRequestPolicyOptions
+	responseHeadersPolicy *ResponseHeadersPolicyOptions
+}
+
+// applyPolicyOptions crea las políticas personalizadas y reemplaza las managed
+// policies por defecto del behavior. Los ids de las políticas derivan de id.
+func applyPolicyOptions(scope constructs.Construct, id string, options *awscloudfront.AddBehaviorOptions, overrides policyOverrides) {
+	if overrides.cachePolicy != nil {
+		options.CachePolicy = newCachePolicy(scope, fmt.Sprintf("%s-CachePolicy", id), overrides.cachePolicy)
+	}
+	if overrides.originRequestPolicy != nil {
+		options.OriginRequestPolicy = newOriginRequestPolicy(scope, fmt.Sprintf("%s-OriginRequestPolicy", id), overrides.originRequestPolicy)
+	}
+	if overrides.responseHeadersPolicy != nil {
+		options.ResponseHeadersPolicy = newResponseHeadersPolicy(scope, fmt.Sprintf("%s-ResponseHeadersPolicy", id), overrides.responseHeadersPolicy)
+	}
+}
+
+func newCachePolicy(scope constructs.Construct, id string, opts *CachePolicyOptions) awscloudfront.ICachePolicy {
+	queryStrings := awscloudfront.CacheQueryStringBehavior_None()
+	if opts.AllQueryStrings {
+		queryStrings = awscloudfront.CacheQueryStringBehavior_All()
+	} else if len(opts.QueryStringAllowList) > 0 {
+		queryStrings = awscloudfront.CacheQueryStringBehavior_AllowList(stringPtrs(opts.QueryStringAllowList)...)
+	}
+
+	cookies := awscloudfront.CacheCookieBehavior_None()
+	if len(opts.CookieAllowList) > 0 {
+		cookies = awscloudfront.CacheCookieBehavior_AllowList(stringPtrs(opts.CookieAllowList)...)
+	}
+
+	headers := awscloudfront.CacheHeaderBehavior_None()
+	if len(opts.HeaderAllowList) > 0 {
+		headers = awscloudfront.CacheHeaderBehavior_AllowList(stringPtrs(opts.HeaderAllowList)...)
+	}
+
+	return awscloudfront.NewCachePolicy(scope, jsii.String(id), &awscloudfront.CachePolicyProps{
+		CachePolicyName:            optionalString(opts.Name),
+		Comment:                    jsii.String(fmt.Sprintf("Cache policy for %s", id)),
+		DefaultTtl:                 opts.DefaultTTL,
+		MinTtl:                     opts.MinTTL,
+		MaxTtl:                     opts.MaxTTL,
+		QueryStringBehavior:        queryStrings,
+		CookieBehavior:             cookies,
+		HeaderBehavior:             headers,
+		EnableAcceptEncodingGzip:   jsii.Bool(true),
+		EnableAcceptEncodingBrotli: jsii.Bool(true),
+	})
+}
+
+func newOriginRequestPolicy(scope constructs.Construct, id string, opts *OriginRequestPolicyOptions) awscloudfront.IOriginRequestPolicy {
+	queryStrings := awscloudfront.OriginRequestQueryStringBehavior_None()
+	if opts.AllQueryStrings {
+		queryStrings = awscloudfront.OriginRequestQueryStringBehavior_All()
+	} else if len(opts.QueryStringAllowList) > 0 {
+		queryStrings = awscloudfront.OriginRequestQueryStringBehavior_AllowList(stringPtrs(opts.QueryStringAllowList)...)
+	}
+
+	cookies := awscloudfront.OriginRequestCookieBehavior_None()
+	if opts.AllCookies {
+		cookies = awscloudfront.OriginRequestCookieBehavior_All()
+	} else if len(opts.CookieAllowList) > 0 {
+		cookies = awscloudfront.OriginRequestCookieBehavior_AllowList(stringPtrs(opts.CookieAllowList)...)
+	}
+
+	headers := awscloudfront.OriginRequestHeaderBehavior_None()
+	if opts.AllViewerHeaders {
+		headers = awscloudfront.OriginRequestHeaderBehavior_All()
+	} else if len(opts.HeaderAllowList) > 0 {
+		headers = awscloudfront.OriginRequestHeaderBehavior_AllowList(stringPtrs(opts.HeaderAllowList)...)
+	}
+
+	return awscloudfront.NewOriginRequestPolicy(scope, jsii.String(id), &awscloudfront.OriginRequestPolicyProps{
+		OriginRequestPolicyName: optionalString(opts.Name),
+		Comment:                 jsii.String(fmt.Sprintf("Origin request policy for %s", id)),
+		QueryStringBehavior:     queryStrings,
+		CookieBehavior:          cookies,
+		HeaderBehavior:          headers,
+	})
+}
+
+func newResponseHeadersPolicy(scope constructs.Construct, id string, opts *ResponseHeadersPolicyOptions) awscloudfront.IResponseHeadersPolicy {
+	// Security headers base (equivalentes a la managed policy SECURITY_HEADERS)
+	hstsMaxAge := opts.HSTSMaxAge
+	if hstsMaxAge == nil {
+		hstsMaxAge = awscdk.Duration_Days(jsii.Number(365))
+	}
+
+	frameOption := opts.FrameOption
+	if frameOption == "" {
+		frameOption = awscloudfront.HeadersFrameOption_DENY
+	}
+
+	referrerPolicy := opts.ReferrerPolicy
+	if referrerPolicy == "" {
+		referrerPolicy = awscloudfront.HeadersReferrerPolicy_STRICT_ORIGIN_WHEN_CROSS_ORIGIN
+	}
+
+	securityHeaders := &awscloudfront.ResponseSecurityHeadersBehavior{
+		ContentTypeOptions: &awscloudfront.ResponseHeadersContentTypeOptions{
+			Override: jsii.Bool(true),
+		},
+		FrameOptions: &awscloudfront.ResponseHeadersFrameOptions{
+			FrameOption: frameOption,
+			Override:    jsii.Bool(true),
+		},
+		ReferrerPolicy: &awscloudfront.ResponseHeadersReferrerPolicy{
+			ReferrerPolicy: referrerPolicy,
+			Override:       jsii.Bool(true),
+		},
+		StrictTransportSecurity: &awscloudfront.ResponseHeadersStrictTransportSecurity{
+			AccessControlMaxAge: hstsMaxAge,
+			IncludeSubdomains:   jsii.Bool(opts.HSTSIncludeSubdomains),
+			Preload:             jsii.Bool(opts.HSTSPreload),
+			Override:            jsii.Bool(true),
+		},
+		XssProtection: &awscloudfront.ResponseHeadersXSSProtection{
+			Protection: jsii.Bool(true),
+			ModeBlock:  jsii.Bool(true),
+			Override:   jsii.Bool(true),
+		},
+	}
+
+	if opts.ContentSecurityPolicy != "" {
+		securityHeaders.ContentSecurityPolicy = &awscloudfront.ResponseHeadersContentSecurityPolicy{
+			ContentSecurityPolicy: jsii.String(opts.ContentSecurityPolicy),
+			Override:              jsii.Bool(true),
+		}
+	}
+
+	policyProps := &awscloudfront.ResponseHeadersPolicyProps{
+		ResponseHeadersPolicyName: optionalString(opts.Name),
+		Comment:                   jsii.String(fmt.Sprintf("Response headers policy for %s", id)),
+		SecurityHeadersBehavior:   securityHeaders,
+	}
+
+	// CORS (opcional)
+	if opts.CORS != nil {
+		if len(opts.CORS.AllowOrigins) == 0 {
+			panic(fmt.Sprintf("%s: CORS requiere al menos un origen en AllowOrigins", id))
+		}
+
+		allowMethods := opts.CORS.AllowMethods
+		if len(allowMethods) == 0 {
+			allowMethods = []string{"GET", "HEAD", "OPTIONS"}
+		}
+
+		allowHeaders := opts.CORS.AllowHeaders
+		if len(allowHeaders) == 0 {
+			allowHeaders = []string{"*"}
+		}
+
+		cors := &awscloudfront.ResponseHeadersCorsBehavior{
+			AccessControlAllowOrigins:     jsii.Strings(opts.CORS.AllowOrigins...),
+			AccessControlAllowMethods:     jsii.Strings(allowMethods...),
+			AccessControlAllowHeaders:     jsii.Strings(allowHeaders...),
+			AccessControlAllowCredentials: jsii.Bool(opts.CORS.AllowCredentials),
+			AccessControlMaxAge:           opts.CORS.MaxAge,
+			OriginOverride:                jsii.Bool(true),
+		}
+		if len(opts.CORS.ExposeHeaders) > 0 {
+			cors.AccessControlExposeHeaders = jsii.Strings(opts.CORS.ExposeHeaders...)
+		}
+		policyProps.CorsBehavior = cors
+	}
+
+	// Headers personalizados (ordenados para que el template sea determinístico)
+	if len(opts.CustomHeaders) > 0 {
+		names := make([]string, 0, len(opts.CustomHeaders))
+		for name := range opts.CustomHeaders {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		customHeaders := make([]*awscloudfront.ResponseCustomHeader, 0, len(names))
+		for _, name := range names {
+			customHeaders = append(customHeaders, &awscloudfront.ResponseCustomHeader{
+				Header:   jsii.String(name),
+				Value:    jsii.String(opts.CustomHeaders[name]),
+				Override: jsii.Bool(true),
+			})
+		}
+		policyProps.CustomHeadersBehavior = &awscloudfront.ResponseCustomHeadersBehavior{
+			CustomHeaders: &customHeaders,
+		}
+	}
+
+	if len(opts.RemoveHeaders) > 0 {
+		policyProps.RemoveHeaders = jsii.Strings(opts.RemoveHeaders...)
+	}
+
+	return awscloudfront.NewResponseHeadersPolicy(scope, jsii.String(id), policyProps)
+}
+
+// stringPtrs convierte []string en []*string para los métodos variádicos de jsii.
+func stringPtrs(values []string) []*string {
+	ptrs := make([]*string, len(values))
+	for i, v := range values {
+		ptrs[i] = jsii.String(v)
+	}
+	return ptrs
+}
+
+// optionalString devuelve nil para strings vacíos (deja que CDK genere el valor).
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return jsii.String(value)
+}
@@ -3,11 +3,7 @@ package cloudfront
 import (
 	"fmt"
 
-	"github.com/aws/aws-cdk-go/awscdk/v2"
-	"github.com/aws/aws-cdk-go/awscdk/v2/awscertificatemanager"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfront"
-	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfrontorigins"
-	"github.com/aws/aws-cdk-go/awscdk/v2/awsiam"
 	"github.com/aws/constructs-go/constructs/v10"
 	"github.com/aws/jsii-runtime-go"
 )
@@ -23,114 +19,56 @@ func (s *S3CloudFrontStrategy) Build(scope constructs.Construct, id string, prop
 	}
 
 	// =============================================================================
-	// 2. CREAR OAC (Origin Access Control)
+	// 2. CONFIGURAR DISTRIBUTION PROPS
 	// =============================================================================
-	oac := awscloudfront.NewS3OriginAccessControl(scope, jsii.String(fmt.Sprintf("%s-OAC", id)), &awscloudfront.S3OriginAccessControlProps{
-		Description: jsii.String(fmt.Sprintf("OAC for %s", id)),
-	})
-
-	// =============================================================================
-	// 3. CONFIGURAR DISTRIBUTION PROPS
-	// =============================================================================
-	distributionProps := &awscloudfront.DistributionProps{
-		Comment:           jsii.String(props.Comment),
-		DefaultRootObject: jsii.String("index.html"),
-		HttpVersion:       awscloudfront.HttpVersion_HTTP2_AND_3,
-		EnableIpv6:        jsii.Bool(true),
-		EnableLogging:     jsii.Bool(props.EnableAccessLogging),
-		PriceClass:        awscloudfront.PriceClass_PRICE_CLASS_100,
+	defaultRootObject := props.DefaultRootObject
+	if defaultRootObject == "" {
+		defaultRootObject = "index.html"
 	}
 
-	// SSL/TLS (opcional)
-	if props.CertificateArn != "" {
-		cert := awscertificatemanager.Certificate_FromCertificateArn(
-			scope,
-			jsii.String(fmt.Sprintf("%s-Cert", id)),
-			jsii.String(props.CertificateArn),
-		)
-		distributionProps.Certificate = cert
-		distributionProps.MinimumProtocolVersion = awscloudfront.SecurityPolicyProtocol_TLS_V1_2_2021
-		distributionProps.SslSupportMethod = awscloudfront.SSLMethod_SNI
-	}
+	distributionProps := newDistributionProps(props)
+	distributionProps.DefaultRootObject = jsii.String(defaultRootObject)
 
-	// Dominio personalizado (opcional)
-	if len(props.DomainNames) > 0 {
-		var domains []*string
-		for _, d := range props.DomainNames {
-			domains = append(domains, jsii.String(d))
-		}
-		distributionProps.DomainNames = &domains
-	}
+	// SSL/TLS y dominios personalizados (opcional)
+	applyCustomDomain(scope, id, props, distributionProps)
+
+	// Logging y recursos compartidos por los behaviors (real-time logs, key group)
+	shared := newSharedBehaviorConfig(scope, id, props, distributionProps)
 
 	// =============================================================================
-	// 4. DEFAULT BEHAVIOR
-	// =============================================================================
-	distributionProps.DefaultBehavior = &awscloudfront.BehaviorOptions{
-		Origin: awscloudfrontorigins.S3BucketOrigin_WithOriginAccessControl(
-			props.S3Bucket,
-			&awscloudfrontorigins.S3BucketOriginWithOACProps{
-				OriginAccessControl: oac,
-			},
-		),
-		ViewerProtocolPolicy:  awscloudfront.ViewerProtocolPolicy_REDIRECT_TO_HTTPS,
-		AllowedMethods:        awscloudfront.AllowedMethods_ALLOW_GET_HEAD_OPTIONS(),
-		CachedMethods:         awscloudfront.CachedMethods_CACHE_GET_HEAD_OPTIONS(),
-		CachePolicy:           awscloudfront.CachePolicy_CACHING_OPTIMIZED(),
-		ResponseHeadersPolicy: awscloudfront.ResponseHeadersPolicy_SECURITY_HEADERS(),
-		Compress:              jsii.Bool(true),
-	}
+	// 3. DEFAULT BEHAVIOR (origen S3 con OAC)
+	// =============================================================================
+	distributionProps.DefaultBehavior = newDefaultBehavior(scope, id, props, shared)
 
 	// =============================================================================
-	// 5. ERRORES PERSONALIZADOS PARA SPA (index.html)
-	// =============================================================================
-	distributionProps.ErrorResponses = &[]*awscloudfront.ErrorResponse{
-		{
-			HttpStatus:         jsii.Number(403),
-			ResponseHttpStatus: jsii.Number(200),
-			ResponsePagePath:   jsii.String("/index.html"),
-			Ttl:                awscdk.Duration_Minutes(jsii.Number(5)),
-		},
-		{
-			HttpStatus:         jsii.Number(404),
-			ResponseHttpStatus: jsii.Number(200),
-			ResponsePagePath:   jsii.String("/index.html"),
-			Ttl:                awscdk.Duration_Minutes(jsii.Number(5)),
-		},
-	}
+	// 4. PÁGINAS DE ERROR SEGÚN EL MODO DE SITIO (SPA / estático / custom)
+	// =============================================================================
+	applySiteMode(props, distributionProps)
 
 	// =============================================================================
-	// 6. WAF (si aplica)
+	// 5. WAF (si aplica)
 	// =============================================================================
-	if props.WebAclArn != "" {
-		distributionProps.WebAclId = jsii.String(props.WebAclArn)
-	}
+	applyWebAcl(props, distributionProps)
 
 	// =============================================================================
-	// 7. CREAR DISTRIBUTION
+	// 6. CREAR DISTRIBUTION
 	// =============================================================================
 	distribution := awscloudfront.NewDistribution(scope, jsii.String(fmt.Sprintf("%s-Distribution", id)), distributionProps)
 
+	// Registros alias A/AAAA en Route53 (si hay HostedZone)
+	addAliasRecords(scope, id, props, distribution)
+
 	// =============================================================================
-	// 8. POLÍTICA S3 PARA OAC
+	// 7. POLÍTICA S3 PARA OAC
 	// =============================================================================
 	if props.AutoConfigureS3BucketPolicy {
-		props.S3Bucket.AddToResourcePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
-			Sid:    jsii.String("AllowCloudFrontServicePrincipal"),
-			Effect: awsiam.Effect_ALLOW,
-			Principals: &[]awsiam.IPrincipal{
-				awsiam.NewServicePrincipal(jsii.String("cloudfront.amazonaws.com"), nil),
-			},
-			Actions: jsii.Strings("s3:GetObject"),
-			Resources: jsii.Strings(
-				fmt.Sprintf("%s/*", *props.S3Bucket.BucketArn()),
-			),
-			Conditions: &map[string]interface{}{
-				"StringEquals": map[string]interface{}{
-					"AWS:SourceArn": *distribution.DistributionArn(),
-				},
-			},
-		}))
+		grantCloudFrontRead(props.S3Bucket, distribution)
 	}
 
+	// =============================================================================
+	// 8. BEHAVIORS ADICIONALES (routing por path pattern)
+	// =============================================================================
+	addAdditionalBehaviors(scope, id, distribution, props, shared)
+
 	return distribution
 }
@@ -0,0 +1,75 @@
+package cloudfront
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfront"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// -----------------------------------------------------------------------------
+// Contenido privado: signed URLs / signed cookies (trusted key groups)
+// -----------------------------------------------------------------------------
+
+// SignedAccessOptions configura el key group con el que CloudFront valida
+// signed URLs y signed cookies. Definir solo uno de PublicKeyFile o KeyGroup.
+//
+// Los behaviors protegidos se marcan con ProtectDefaultBehavior y
+// BehaviorConfig.RequireSignedAccess. Las URLs y cookies se firman en el
+// backend con el paquete cloudfront/signer y la clave privada correspondiente.
+type SignedAccessOptions struct {
+	// Ruta al archivo PEM con la clave pública RSA 2048 (se lee en synth).
+	// Crea "<id>-PublicKey" y "<id>-KeyGroup".
+	PublicKeyFile string
+
+	// Key group existente (ej. creado con NewSignedAccessKeyGroup para
+	// exponer el PublicKeyId al backend como Key-Pair-Id)
+	KeyGroup awscloudfront.IKeyGroup
+
+	// Exige signed URLs/cookies en el DefaultBehavior
+	ProtectDefaultBehavior bool
+}
+
+// NewSignedAccessKeyGroup crea una public key de CloudFront desde un archivo PEM
+// y un key group que la contiene. El PublicKeyId de la key es el Key-Pair-Id
+// que el backend usa al firmar.
+func NewSignedAccessKeyGroup(scope constructs.Construct, id string, publicKeyFile string) (awscloudfront.PublicKey, awscloudfront.KeyGroup) {
+	encodedKey, err := os.ReadFile(publicKeyFile)
+	if err != nil {
+		panic(fmt.Sprintf("No se pudo leer la clave pública %s: %v", publicKeyFile, err))
+	}
+
+	publicKey := awscloudfront.NewPublicKey(scope, jsii.String(fmt.Sprintf("%s-PublicKey", id)), &awscloudfront.PublicKeyProps{
+		EncodedKey: jsii.String(string(encodedKey)),
+		Comment:    jsii.String(fmt.Sprintf("Signed URLs/cookies public key for %s", id)),
+	})
+
+	keyGroup := awscloudfront.NewKeyGroup(scope, jsii.String(fmt.Sprintf("%s-KeyGroup", id)), &awscloudfront.KeyGroupProps{
+		Items:   &[]awscloudfront.IPublicKey{publicKey},
+		Comment: jsii.String(fmt.Sprintf("Signed URLs/cookies key group for %s", id)),
+	})
+
+	return publicKey, keyGroup
+}
+
+// newSignedAccessKeyGroup resuelve el key group de props.SignedAccess (nil si no aplica).
+func newSignedAccessKeyGroup(scope constructs.Construct, id string, props CloudFrontPropertiesV2) awscloudfront.IKeyGroup {
+	options := props.SignedAccess
+	if options == nil {
+		return nil
+	}
+
+	switch {
+	case options.KeyGroup != nil && options.PublicKeyFile != "":
+		panic("SignedAccess: defina PublicKeyFile o KeyGroup, no ambos")
+	case options.KeyGroup != nil:
+		return options.KeyGroup
+	case options.PublicKeyFile != "":
+		_, keyGroup := NewSignedAccessKeyGroup(scope, id, options.PublicKeyFile)
+		return keyGroup
+	default:
+		panic("SignedAccess requiere PublicKeyFile o KeyGroup")
+	}
+}
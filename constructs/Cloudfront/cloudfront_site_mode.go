@@ -0,0 +1,148 @@
+package cloudfront
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscloudfront"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// -----------------------------------------------------------------------------
+// ENUM: Modos de sitio (solo OriginTypeS3)
+// -----------------------------------------------------------------------------
+type SiteMode string
+
+const (
+	// SiteModeSPA reescribe 403/404 a /index.html con status 200 (routing del cliente).
+	// Es el modo por defecto.
+	SiteModeSPA SiteMode = "SPA"
+
+	// SiteModeStatic sirve el 404.html real con status 404 y agrega una
+	// CloudFront Function que reescribe "/about/" a "/about/index.html".
+	SiteModeStatic SiteMode = "STATIC"
+
+	// SiteModeCustom usa únicamente los mapeos definidos en ErrorPages.
+	SiteModeCustom SiteMode = "CUSTOM"
+)
+
+// ErrorPageMapping define la respuesta de CloudFront ante un código de error del origen.
+type ErrorPageMapping struct {
+	// Código HTTP devuelto por el origen (obligatorio). Ej: 404
+	HttpStatus float64
+
+	// Código HTTP devuelto al viewer (opcional). Por defecto: el mismo HttpStatus
+	ResponseHttpStatus float64
+
+	// Página servida en lugar del error (opcional). Ej: "/404.html"
+	ResponsePagePath string
+
+	// Tiempo que CloudFront cachea la respuesta de error. Por defecto: 5 minutos
+	Ttl awscdk.Duration
+}
+
+// staticRewriteFunctionCode agrega index.html a las URIs de directorio:
+// "/about/" -> "/about/index.html" y "/about" -> "/about/index.html".
+const staticRewriteFunctionCode = `function handler(event) {
+    var request = event.request;
+    var uri = request.uri;
+
+    if (uri.endsWith('/')) {
+        request.uri += 'index.html';
+    } else if (!uri.split('/').pop().includes('.')) {
+        request.uri += '/index.html';
+    }
+
+    return request;
+}`
+
+// staticRewriteFunction devuelve la función viewer-request del modo estático.
+func staticRewriteFunction() EdgeFunction {
+	return EdgeFunction{
+		EventType: EdgeEventViewerRequest,
+		Code:      staticRewriteFunctionCode,
+	}
+}
+
+// siteModeEdgeFunctions combina las funciones edge del DefaultBehavior con las
+// que requiere el modo de sitio (reescritura de index.html en SiteModeStatic).
+func siteModeEdgeFunctions(props CloudFrontPropertiesV2) []EdgeFunction {
+	if props.OriginType != OriginTypeS3 || props.SiteMode != SiteModeStatic {
+		return props.EdgeFunctions
+	}
+
+	for _, f := range props.EdgeFunctions {
+		if f.EventType == EdgeEventViewerRequest {
+			panic("SiteModeStatic ya asocia una función viewer-request al DefaultBehavior (reescritura de index.html); " +
+				"use SiteModeCustom o incluya la reescritura en su propia función")
+		}
+	}
+
+	return append([]EdgeFunction{staticRewriteFunction()}, props.EdgeFunctions...)
+}
+
+// applySiteMode configura las páginas de error según props.SiteMode.
+// La función de reescritura del modo estático se asocia en newDefaultBehavior.
+func applySiteMode(props CloudFrontPropertiesV2, distributionProps *awscloudfront.DistributionProps) {
+	var mappings []ErrorPageMapping
+
+	switch props.SiteMode {
+	case SiteModeSPA, "":
+		mappings = []ErrorPageMapping{
+			{HttpStatus: 403, ResponseHttpStatus: 200, ResponsePagePath: "/index.html"},
+			{HttpStatus: 404, ResponseHttpStatus: 200, ResponsePagePath: "/index.html"},
+		}
+
+	case SiteModeStatic:
+		notFoundPage := props.NotFoundPagePath
+		if notFoundPage == "" {
+			notFoundPage = "/404.html"
+		}
+		// 403: S3 responde 403 a objetos inexistentes cuando OAC no tiene s3:ListBucket
+		mappings = []ErrorPageMapping{
+			{HttpStatus: 403, ResponseHttpStatus: 404, ResponsePagePath: notFoundPage},
+			{HttpStatus: 404, ResponseHttpStatus: 404, ResponsePagePath: notFoundPage},
+		}
+
+	case SiteModeCustom:
+		if len(props.ErrorPages) == 0 {
+			panic("SiteModeCustom requiere al menos un ErrorPageMapping (props.ErrorPages)")
+		}
+		mappings = props.ErrorPages
+
+	default:
+		panic(fmt.Sprintf("SiteMode no soportado: %s", props.SiteMode))
+	}
+
+	distributionProps.ErrorResponses = newErrorResponses(mappings)
+}
+
+// newErrorResponses convierte los ErrorPageMapping en ErrorResponses de CloudFront.
+func newErrorResponses(mappings []ErrorPageMapping) *[]*awscloudfront.ErrorResponse {
+	var responses []*awscloudfront.ErrorResponse
+	for i, m := range mappings {
+		if m.HttpStatus == 0 {
+			panic(fmt.Sprintf("ErrorPages[%d]: HttpStatus es obligatorio", i))
+		}
+
+		ttl := m.Ttl
+		if ttl == nil {
+			ttl = awscdk.Duration_Minutes(jsii.Number(5))
+		}
+
+		response := &awscloudfront.ErrorResponse{
+			HttpStatus: jsii.Number(m.HttpStatus),
+			Ttl:        ttl,
+		}
+		if m.ResponsePagePath != "" {
+			responseStatus := m.ResponseHttpStatus
+			if responseStatus == 0 {
+				responseStatus = m.HttpStatus
+			}
+			response.ResponseHttpStatus = jsii.Number(responseStatus)
+			response.ResponsePagePath = jsii.String(m.ResponsePagePath)
+		}
+		responses = append(responses, response)
+	}
+	return &responses
+}
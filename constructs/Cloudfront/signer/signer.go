@@ -0,0 +1,331 @@
+// Package signer firma URLs y cookies de CloudFront para contenido privado
+// (distribuciones con SignedAccess). Solo depende de la librería estándar, para
+// que los backends (Lambdas, servicios en ECS) puedan emitir links sin el SDK.
+//
+// Uso:
+//
+//	s, err := signer.NewFromFile(keyPairID, "/secrets/cloudfront-private.pem")
+//	url, err := s.SignURL("https://media.example.com/videos/intro.mp4", time.Now().Add(time.Hour))
+//	cookies, err := s.SignCookiesWithPolicy(signer.Policy{
+//	    Resource: "https://media.example.com/videos/*",
+//	    Expires:  time.Now().Add(12 * time.Hour),
+//	})
+//
+// keyPairID es el id de la public key de CloudFront (PublicKeyId), no un key pair
+// de la cuenta root.
+package signer
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Nombres de las cookies que CloudFront valida
+const (
+	CookiePolicy    = "CloudFront-Policy"
+	CookieSignature = "CloudFront-Signature"
+	CookieKeyPairID = "CloudFront-Key-Pair-Id"
+	CookieExpires   = "CloudFront-Expires"
+)
+
+// Signer firma URLs y cookies con la clave privada asociada a una public key de CloudFront.
+type Signer struct {
+	keyPairID  string
+	privateKey *rsa.PrivateKey
+}
+
+// Policy define una custom policy. Resource admite comodines ("*") y puede
+// omitirse al firmar URLs (se usa la URL firmada).
+type Policy struct {
+	Resource string
+
+	// Fecha de expiración (obligatoria)
+	Expires time.Time
+
+	// Fecha a partir de la cual el acceso es válido (opcional)
+	NotBefore time.Time
+
+	// IP o rango CIDR autorizado (opcional). Ej: "192.0.2.0/24"
+	IPAddress string
+}
+
+// New crea un Signer a partir de la clave privada RSA en formato PEM (PKCS#1 o PKCS#8).
+func New(keyPairID string, privateKeyPEM []byte) (*Signer, error) {
+	if keyPairID == "" {
+		return nil, errors.New("signer: keyPairID es obligatorio")
+	}
+
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, errors.New("signer: no se encontró un bloque PEM en la clave privada")
+	}
+
+	privateKey, err := parsePrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signer{keyPairID: keyPairID, privateKey: privateKey}, nil
+}
+
+// NewFromFile crea un Signer leyendo la clave privada PEM desde un archivo.
+func NewFromFile(keyPairID, path string) (*Signer, error) {
+	privateKeyPEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("signer: leyendo clave privada: %w", err)
+	}
+	return New(keyPairID, privateKeyPEM)
+}
+
+// SignURL firma una URL con canned policy (solo fecha de expiración).
+func (s *Signer) SignURL(rawURL string, expires time.Time) (string, error) {
+	policy, err := cannedPolicy(rawURL, expires)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := s.sign(policy)
+	if err != nil {
+		return "", err
+	}
+
+	return appendQuery(rawURL, []queryParam{
+		{"Expires", strconv.FormatInt(expires.Unix(), 10)},
+		{"Signature", signature},
+		{"Key-Pair-Id", s.keyPairID},
+	})
+}
+
+// SignURLWithPolicy firma una URL con custom policy (comodines, NotBefore, IP).
+// Si policy.Resource está vacío se usa rawURL.
+func (s *Signer) SignURLWithPolicy(rawURL string, policy Policy) (string, error) {
+	if policy.Resource == "" {
+		policy.Resource = rawURL
+	}
+
+	document, err := policy.document()
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := s.sign(document)
+	if err != nil {
+		return "", err
+	}
+
+	return appendQuery(rawURL, []queryParam{
+		{"Policy", encode(document)},
+		{"Signature", signature},
+		{"Key-Pair-Id", s.keyPairID},
+	})
+}
+
+// SignCookies genera signed cookies con canned policy para un recurso exacto.
+// Las cookies se devuelven con Path "/", Secure y HttpOnly; el caller define Domain.
+func (s *Signer) SignCookies(resource string, expires time.Time) ([]*http.Cookie, error) {
+	policy, err := cannedPolicy(resource, expires)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.sign(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Cookie{
+		newCookie(CookieExpires, strconv.FormatInt(expires.Unix(), 10), expires),
+		newCookie(CookieSignature, signature, expires),
+		newCookie(CookieKeyPairID, s.keyPairID, expires),
+	}, nil
+}
+
+// SignCookiesWithPolicy genera signed cookies con custom policy (ej. "https://cdn.example.com/private/*").
+// Las cookies se devuelven con Path "/", Secure y HttpOnly; el caller define Domain.
+func (s *Signer) SignCookiesWithPolicy(policy Policy) ([]*http.Cookie, error) {
+	if policy.Resource == "" {
+		return nil, errors.New("signer: Policy.Resource es obligatorio para signed cookies")
+	}
+
+	document, err := policy.document()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.sign(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Cookie{
+		newCookie(CookiePolicy, encode(document), policy.Expires),
+		newCookie(CookieSignature, signature, policy.Expires),
+		newCookie(CookieKeyPairID, s.keyPairID, policy.Expires),
+	}, nil
+}
+
+// sign firma el documento de policy con RSA-SHA1 (PKCS#1 v1.5), como exige CloudFront.
+func (s *Signer) sign(document []byte) (string, error) {
+	digest := sha1.Sum(document)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA1, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("signer: firmando policy: %w", err)
+	}
+	return encode(signature), nil
+}
+
+// -----------------------------------------------------------------------------
+// Policies
+// -----------------------------------------------------------------------------
+
+type policyDocument struct {
+	Statement []policyStatement `json:"Statement"`
+}
+
+type policyStatement struct {
+	Resource  string          `json:"Resource"`
+	Condition policyCondition `json:"Condition"`
+}
+
+type policyCondition struct {
+	DateLessThan    epochTime  `json:"DateLessThan"`
+	DateGreaterThan *epochTime `json:"DateGreaterThan,omitempty"`
+	IPAddress       *sourceIP  `json:"IpAddress,omitempty"`
+}
+
+type epochTime struct {
+	EpochTime int64 `json:"AWS:EpochTime"`
+}
+
+type sourceIP struct {
+	SourceIP string `json:"AWS:SourceIp"`
+}
+
+// cannedPolicy genera la canned policy que CloudFront reconstruye a partir de
+// Expires; debe coincidir byte a byte (sin espacios ni escapes HTML).
+func cannedPolicy(resource string, expires time.Time) ([]byte, error) {
+	if expires.IsZero() {
+		return nil, errors.New("signer: la fecha de expiración es obligatoria")
+	}
+
+	return marshalPolicy(policyDocument{
+		Statement: []policyStatement{{
+			Resource:  resource,
+			Condition: policyCondition{DateLessThan: epochTime{expires.Unix()}},
+		}},
+	})
+}
+
+// document genera el JSON de la custom policy.
+func (p Policy) document() ([]byte, error) {
+	if p.Expires.IsZero() {
+		return nil, errors.New("signer: Policy.Expires es obligatorio")
+	}
+
+	condition := policyCondition{DateLessThan: epochTime{p.Expires.Unix()}}
+	if !p.NotBefore.IsZero() {
+		condition.DateGreaterThan = &epochTime{p.NotBefore.Unix()}
+	}
+	if p.IPAddress != "" {
+		ip := p.IPAddress
+		if !strings.Contains(ip, "/") {
+			ip += "/32"
+		}
+		condition.IPAddress = &sourceIP{ip}
+	}
+
+	return marshalPolicy(policyDocument{
+		Statement: []policyStatement{{Resource: p.Resource, Condition: condition}},
+	})
+}
+
+// marshalPolicy serializa sin escapar &, < y > (json.Marshal los convierte a \u00XX).
+func marshalPolicy(document policyDocument) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(document); err != nil {
+		return nil, fmt.Errorf("signer: serializando policy: %w", err)
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// -----------------------------------------------------------------------------
+// Helpers
+// -----------------------------------------------------------------------------
+
+// encode aplica base64 con los reemplazos URL-safe de CloudFront: + -> -, = -> _, / -> ~
+func encode(data []byte) string {
+	return strings.NewReplacer("+", "-", "=", "_", "/", "~").Replace(base64.StdEncoding.EncodeToString(data))
+}
+
+type queryParam struct {
+	key, value string
+}
+
+// appendQuery agrega los parámetros de firma al final de la URL, preservando el
+// query string existente (el orden importa para la canned policy).
+func appendQuery(rawURL string, params []queryParam) (string, error) {
+	if _, err := url.Parse(rawURL); err != nil {
+		return "", fmt.Errorf("signer: URL inválida: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString(rawURL)
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+	for _, p := range params {
+		b.WriteString(separator)
+		b.WriteString(p.key)
+		b.WriteString("=")
+		b.WriteString(p.value)
+		separator = "&"
+	}
+	return b.String(), nil
+}
+
+func newCookie(name, value string, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Expires:  expires,
+		Secure:   true,
+		HttpOnly: true,
+	}
+}
+
+// parsePrivateKey acepta claves RSA en PKCS#1 ("RSA PRIVATE KEY") o PKCS#8 ("PRIVATE KEY").
+func parsePrivateKey(der []byte) (*rsa.PrivateKey, error) {
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("signer: clave privada inválida: %w", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("signer: CloudFront solo admite claves RSA")
+	}
+	return rsaKey, nil
+}
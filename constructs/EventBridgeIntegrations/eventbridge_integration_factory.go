@@ -13,6 +13,9 @@ type IntegrationType string
 const (
 	// IntegrationTypeS3ToLambda creates an integration from S3 bucket events to Lambda function
 	IntegrationTypeS3ToLambda IntegrationType = "S3_TO_LAMBDA"
+
+	// IntegrationTypeS3ToSQS creates an integration from S3 bucket events to an SQS queue (batch buffering)
+	IntegrationTypeS3ToSQS IntegrationType = "S3_TO_SQS"
 )
 
 // EventBridgeIntegrationFactoryProps defines properties for creating an EventBridge integration via Factory
@@ -22,6 +25,9 @@ type EventBridgeIntegrationFactoryProps struct {
 
 	// Configuration specific to S3ToLambda integration
 	S3ToLambdaConfig *S3ToLambdaConfig
+
+	// Configuration specific to S3ToSQS integration
+	S3ToSQSConfig *S3ToSQSConfig
 }
 
 // NewEventBridgeIntegrationFactory creates an EventBridge integration using the Factory + Strategy pattern
@@ -59,6 +65,12 @@ func NewEventBridgeIntegrationFactory(
 		}
 		strategy = &EventBridgeS3ToLambdaStrategy{}
 
+	case IntegrationTypeS3ToSQS:
+		if props.S3ToSQSConfig == nil {
+			panic("S3ToSQSConfig is required when IntegrationType is S3_TO_SQS")
+		}
+		strategy = &EventBridgeS3ToSQSStrategy{}
+
 	default:
 		panic(fmt.Sprintf("Unsupported IntegrationType: %s", props.IntegrationType))
 	}
@@ -1,6 +1,8 @@
 package eventbridgeintegrations
 
 import (
+	guardduty "cdk-library/constructs/GuardDuty"
+
 	"github.com/aws/aws-cdk-go/awscdk/v2"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awsevents"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awseventstargets"
@@ -46,6 +48,13 @@ type S3ToLambdaConfig struct {
 	// If true, creates an SQS queue to capture events that fail after all retries
 	// Optional: defaults to false if nil
 	EnableDLQ *bool
+
+	// Trigger only for objects scanned as NO_THREATS_FOUND by GuardDuty Malware Protection for S3
+	// Requires a malware protection plan on the bucket (guardduty.NewS3MalwareProtectionPlan)
+	// The Lambda receives the "GuardDuty Malware Protection Object Scan Result" event
+	// (detail.s3ObjectDetails.bucketName / objectKey) instead of the S3 event. EventTypes must be empty
+	// Optional: defaults to false if nil
+	RequireMalwareScanClean *bool
 }
 
 // EventBridgeS3ToLambdaStrategy implements the strategy for S3→Lambda integration
@@ -90,52 +99,26 @@ func (s *EventBridgeS3ToLambdaStrategy) Build(
 		})
 	}
 
-	// 2. Build event pattern detail configuration
-	detailConfig := make(map[string]interface{})
-
-	// Filter by specific bucket name (extracted from instance)
-	detailConfig["bucket"] = map[string]interface{}{
-		"name": []interface{}{*config.SourceBucket.BucketName()},
-	}
-
-	// Filter by object key prefix/suffix (if provided)
-	objectFilter := make(map[string]interface{})
-	if config.ObjectKeyPrefix != nil {
-		objectFilter["prefix"] = *config.ObjectKeyPrefix
-	}
-	if config.ObjectKeySuffix != nil {
-		objectFilter["suffix"] = *config.ObjectKeySuffix
-	}
-
-	if len(objectFilter) > 0 {
-		detailConfig["object"] = map[string]interface{}{
-			"key": []interface{}{objectFilter},
+	// 2. Build event pattern (S3 events, or clean malware scan results)
+	var eventPattern *awsevents.EventPattern
+	if config.RequireMalwareScanClean != nil && *config.RequireMalwareScanClean {
+		if len(config.EventTypes) > 0 {
+			panic("S3ToLambdaConfig.EventTypes cannot be combined with RequireMalwareScanClean (the rule matches malware scan results)")
 		}
+		eventPattern = guardduty.MalwareScanResultEventPattern(*config.SourceBucket.BucketName(),
+			config.ObjectKeyPrefix, config.ObjectKeySuffix, guardduty.MalwareScanNoThreatsFound)
+	} else {
+		eventPattern = s3EventPattern(config.SourceBucket, config.ObjectKeyPrefix, config.ObjectKeySuffix, config.EventTypes)
 	}
 
-	// 3. Configure event types (default to "Object Created")
-	eventTypes := config.EventTypes
-	if eventTypes == nil || len(eventTypes) == 0 {
-		eventTypes = []string{"Object Created"}
-	}
-
-	detailTypes := make([]*string, len(eventTypes))
-	for i, et := range eventTypes {
-		detailTypes[i] = jsii.String(et)
-	}
-
-	// 4. Create EventBridge Rule with S3 event pattern
+	// 3. Create EventBridge Rule with the event pattern
 	rule := awsevents.NewRule(scope, jsii.String(id+"-Rule"), &awsevents.RuleProps{
-		RuleName:    jsii.String(id + "-rule"),
-		Description: jsii.String("Routes S3 events from " + *config.SourceBucket.BucketName() + " to Lambda " + *config.TargetLambda.FunctionName()),
-		EventPattern: &awsevents.EventPattern{
-			Source:     jsii.Strings("aws.s3"),
-			DetailType: &detailTypes,
-			Detail:     &detailConfig,
-		},
+		RuleName:     jsii.String(id + "-rule"),
+		Description:  jsii.String("Routes S3 events from " + *config.SourceBucket.BucketName() + " to Lambda " + *config.TargetLambda.FunctionName()),
+		EventPattern: eventPattern,
 	})
 
-	// 5. Configure Lambda target with retry policy
+	// 4. Configure Lambda target with retry policy
 	targetProps := &awseventstargets.LambdaFunctionProps{}
 
 	if config.MaxRetryAttempts != nil {
@@ -150,13 +133,55 @@ func (s *EventBridgeS3ToLambdaStrategy) Build(
 		targetProps.DeadLetterQueue = dlq
 	}
 
-	// 6. Add Lambda as target to the rule
+	// 5. Add Lambda as target to the rule
 	// CDK automatically configures IAM permissions for EventBridge to invoke Lambda
 	rule.AddTarget(awseventstargets.NewLambdaFunction(config.TargetLambda, targetProps))
 
-	// 7. Enable EventBridge notifications on the S3 bucket
+	// 6. Enable EventBridge notifications on the S3 bucket
 	// This is CRITICAL - without this, S3 won't emit events to EventBridge
 	config.SourceBucket.EnableEventBridgeNotification()
 
 	return rule
 }
+
+// s3EventPattern builds the S3 event pattern filtered by bucket name, object key
+// prefix/suffix and event types (defaults to "Object Created")
+func s3EventPattern(bucket awss3.IBucket, objectKeyPrefix, objectKeySuffix *string, eventTypes []string) *awsevents.EventPattern {
+	detailConfig := make(map[string]interface{})
+
+	// Filter by specific bucket name (extracted from instance)
+	detailConfig["bucket"] = map[string]interface{}{
+		"name": []interface{}{*bucket.BucketName()},
+	}
+
+	// Filter by object key prefix/suffix (if provided)
+	objectFilter := make(map[string]interface{})
+	if objectKeyPrefix != nil {
+		objectFilter["prefix"] = *objectKeyPrefix
+	}
+	if objectKeySuffix != nil {
+		objectFilter["suffix"] = *objectKeySuffix
+	}
+
+	if len(objectFilter) > 0 {
+		detailConfig["object"] = map[string]interface{}{
+			"key": []interface{}{objectFilter},
+		}
+	}
+
+	// Configure event types (default to "Object Created")
+	if eventTypes == nil || len(eventTypes) == 0 {
+		eventTypes = []string{"Object Created"}
+	}
+
+	detailTypes := make([]*string, len(eventTypes))
+	for i, et := range eventTypes {
+		detailTypes[i] = jsii.String(et)
+	}
+
+	return &awsevents.EventPattern{
+		Source:     jsii.Strings("aws.s3"),
+		DetailType: &detailTypes,
+		Detail:     &detailConfig,
+	}
+}
@@ -0,0 +1,204 @@
+package eventbridgeintegrations
+
+import (
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsevents"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awseventstargets"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awss3"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awssqs"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// S3ToSQSConfig defines configuration specific to S3→SQS integration
+type S3ToSQSConfig struct {
+	// Source S3 bucket that emits events (REQUIRED)
+	SourceBucket awss3.IBucket
+
+	// Existing queue that receives the events
+	// Optional: if nil, a queue "<id>-queue" is created with its own DLQ and redrive policy
+	// (returned by NewS3ToSQSIntegration)
+	// An existing queue keeps its own redrive configuration and must be a standard queue (not FIFO)
+	TargetQueue awssqs.IQueue
+
+	// Object key prefix filter (e.g., "uploads/", "data/raw/")
+	// Only objects with keys starting with this prefix will be sent to the queue
+	// Optional: if nil, all objects in the bucket will match
+	ObjectKeyPrefix *string
+
+	// Object key suffix filter (e.g., ".pdf", ".json")
+	// Only objects with keys ending with this suffix will be sent to the queue
+	// Optional: if nil, all file types will match
+	ObjectKeySuffix *string
+
+	// S3 event types to monitor
+	// Common values: "Object Created", "Object Removed", "Object Restore Completed"
+	// Optional: defaults to ["Object Created"] if nil or empty
+	EventTypes []string
+
+	// Visibility timeout of the created queue (should exceed the worker batch processing time)
+	// Optional: defaults to SQS default (30 seconds) if nil. Ignored when TargetQueue is set
+	VisibilityTimeout awscdk.Duration
+
+	// Number of receives before a message is moved to the DLQ of the created queue
+	// Optional: defaults to 5 if nil. Ignored when TargetQueue is set
+	MaxReceiveCount *float64
+
+	// Maximum number of retry attempts for failed deliveries to the queue
+	// Optional: defaults to EventBridge default (185 attempts over 24 hours) if nil
+	MaxRetryAttempts *float64
+
+	// Maximum time to retain events for retry
+	// Optional: defaults to EventBridge default (24 hours) if nil
+	MaxEventAge awscdk.Duration
+
+	// Send a trimmed message instead of the full S3 event:
+	// {"bucket", "key", "size", "etag", "eventType", "time"}
+	// Optional: defaults to false if nil (the full EventBridge event is sent)
+	TrimEvent *bool
+}
+
+// S3ToSQSIntegration is the result of NewS3ToSQSIntegration
+type S3ToSQSIntegration struct {
+	// EventBridge rule matching the S3 events
+	Rule awsevents.Rule
+
+	// Queue receiving the events (created, or S3ToSQSConfig.TargetQueue)
+	// Use it to grant workers access (Queue.GrantConsumeMessages) and read Queue.QueueUrl()
+	Queue awssqs.IQueue
+
+	// DLQ of the created queue, also used for failed EventBridge deliveries
+	// nil when S3ToSQSConfig.TargetQueue is set
+	DeadLetterQueue awssqs.IQueue
+}
+
+// EventBridgeS3ToSQSStrategy implements the strategy for S3→SQS integration
+type EventBridgeS3ToSQSStrategy struct{}
+
+// Build creates the S3 → EventBridge → SQS integration and returns its rule
+// Use NewS3ToSQSIntegration directly to get the created queue and DLQ
+func (s *EventBridgeS3ToSQSStrategy) Build(
+	scope constructs.Construct,
+	id string,
+	props EventBridgeIntegrationFactoryProps,
+) awsevents.Rule {
+	return NewS3ToSQSIntegration(scope, id, *props.S3ToSQSConfig).Rule
+}
+
+// NewS3ToSQSIntegration creates a complete S3 → EventBridge → SQS integration
+//
+// It encapsulates:
+// - EventBridge Rule with S3 event pattern filtering by bucket name and object key
+// - SQS queue as target (created with DLQ and redrive policy, or provided by the caller)
+// - Optional input transformer that trims the event to the object fields
+// - IAM permissions (queue policy automatically configured by CDK)
+// - EventBridge notifications enabled on S3 bucket
+//
+// Architecture:
+//
+//	S3 Bucket (uploads/*) → EventBridge Rule (filter) → SQS Queue → Batch workers
+//	                                 ↓ (delivery failure)    ↓ (maxReceiveCount)
+//	                                 └────────────────→ SQS DLQ (created queue only)
+//
+// Example:
+//
+//	integration := eventbridgeintegrations.NewS3ToSQSIntegration(stack, "UploadsToQueue",
+//	    eventbridgeintegrations.S3ToSQSConfig{
+//	        SourceBucket:    bucket,
+//	        ObjectKeyPrefix: jsii.String("uploads/"),
+//	    })
+//	integration.Queue.GrantConsumeMessages(workerRole)
+func NewS3ToSQSIntegration(scope constructs.Construct, id string, config S3ToSQSConfig) S3ToSQSIntegration {
+
+	// Validate required configuration
+	if config.SourceBucket == nil {
+		panic("S3ToSQSConfig.SourceBucket is required")
+	}
+	if config.TargetQueue != nil && config.TargetQueue.Fifo() != nil && *config.TargetQueue.Fifo() {
+		panic("S3ToSQSConfig.TargetQueue must be a standard queue: FIFO queues are not supported")
+	}
+
+	// 1. Create the queue with its own DLQ and redrive policy (if not provided)
+	queue := config.TargetQueue
+	var dlq awssqs.Queue
+	if queue == nil {
+		maxReceiveCount := 5.0
+		if config.MaxReceiveCount != nil {
+			if *config.MaxReceiveCount < 1 {
+				panic("S3ToSQSConfig.MaxReceiveCount must be at least 1")
+			}
+			maxReceiveCount = *config.MaxReceiveCount
+		}
+
+		dlq = awssqs.NewQueue(scope, jsii.String(id+"-DLQ"), &awssqs.QueueProps{
+			QueueName:       jsii.String(id + "-dlq"),
+			RetentionPeriod: awscdk.Duration_Days(jsii.Number(14)),
+			Encryption:      awssqs.QueueEncryption_SQS_MANAGED,
+			EnforceSSL:      jsii.Bool(true),
+		})
+
+		queue = awssqs.NewQueue(scope, jsii.String(id+"-Queue"), &awssqs.QueueProps{
+			QueueName:         jsii.String(id + "-queue"),
+			VisibilityTimeout: config.VisibilityTimeout,
+			Encryption:        awssqs.QueueEncryption_SQS_MANAGED,
+			EnforceSSL:        jsii.Bool(true),
+			DeadLetterQueue: &awssqs.DeadLetterQueue{
+				Queue:           dlq,
+				MaxReceiveCount: jsii.Number(maxReceiveCount),
+			},
+		})
+	}
+
+	// 2. Create EventBridge Rule with the S3 event pattern (same filters as S3→Lambda)
+	rule := awsevents.NewRule(scope, jsii.String(id+"-Rule"), &awsevents.RuleProps{
+		RuleName:     jsii.String(id + "-rule"),
+		Description:  jsii.String("Routes S3 events from " + *config.SourceBucket.BucketName() + " to SQS queue " + *queue.QueueName()),
+		EventPattern: s3EventPattern(config.SourceBucket, config.ObjectKeyPrefix, config.ObjectKeySuffix, config.EventTypes),
+	})
+
+	// 3. Configure SQS target with retry policy
+	targetProps := &awseventstargets.SqsQueueProps{}
+
+	if config.MaxRetryAttempts != nil {
+		targetProps.RetryAttempts = jsii.Number(*config.MaxRetryAttempts)
+	}
+
+	if config.MaxEventAge != nil {
+		targetProps.MaxEventAge = config.MaxEventAge
+	}
+
+	// Events that cannot be delivered also land in the DLQ of the created queue
+	if dlq != nil {
+		targetProps.DeadLetterQueue = dlq
+	}
+
+	// Trim the message to the fields batch workers need
+	if config.TrimEvent != nil && *config.TrimEvent {
+		targetProps.Message = awsevents.RuleTargetInput_FromObject(map[string]interface{}{
+			"bucket":    awsevents.EventField_FromPath(jsii.String("$.detail.bucket.name")),
+			"key":       awsevents.EventField_FromPath(jsii.String("$.detail.object.key")),
+			"size":      awsevents.EventField_FromPath(jsii.String("$.detail.object.size")),
+			"etag":      awsevents.EventField_FromPath(jsii.String("$.detail.object.etag")),
+			"eventType": awsevents.EventField_DetailType(),
+			"time":      awsevents.EventField_Time(),
+		})
+	}
+
+	// 4. Add SQS queue as target to the rule
+	// CDK automatically adds the queue policy allowing EventBridge to send messages
+	rule.AddTarget(awseventstargets.NewSqsQueue(queue, targetProps))
+
+	// 5. Enable EventBridge notifications on the S3 bucket
+	// This is CRITICAL - without this, S3 won't emit events to EventBridge
+	config.SourceBucket.EnableEventBridgeNotification()
+
+	integration := S3ToSQSIntegration{
+		Rule:  rule,
+		Queue: queue,
+	}
+	if dlq != nil {
+		integration.DeadLetterQueue = dlq
+	}
+
+	return integration
+}
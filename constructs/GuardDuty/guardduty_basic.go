@@ -34,14 +34,25 @@ func (s *GuardDutyBasicStrategy) Build(scope constructs.Construct, id string, pr
 	}
 
 	// Create detector with only foundational data sources (no additional features)
-	detector := awsguardduty.NewCfnDetector(scope, jsii.String(id), &awsguardduty.CfnDetectorProps{
+	detectorProps := &awsguardduty.CfnDetectorProps{
 		Enable: jsii.Bool(true),
 
-		// Foundational data sources are enabled by default:
-		// - CloudTrail Management Events
-		// - VPC Flow Logs
-		// - DNS Logs
-		DataSources: &awsguardduty.CfnDetector_CFNDataSourceConfigurationsProperty{
+		// Export findings every 6 hours (cost-effective for basic monitoring)
+		FindingPublishingFrequency: findingFrequency,
+
+		// Add resource tags if provided
+		Tags: props.Tags,
+	}
+
+	// Foundational data sources are enabled by default:
+	// - CloudTrail Management Events
+	// - VPC Flow Logs
+	// - DNS Logs
+	if isEnabled(props.FeaturesOnly) {
+		// Every feature disabled through the Features list (no deprecated DataSources block)
+		detectorProps.Features = detectorFeatures(nil, resolveDisabledFeatures(props))
+	} else {
+		detectorProps.DataSources = &awsguardduty.CfnDetector_CFNDataSourceConfigurationsProperty{
 			// S3 Logs explicitly disabled (use Comprehensive strategy for S3 protection)
 			S3Logs: &awsguardduty.CfnDetector_CFNS3LogsConfigurationProperty{
 				Enable: jsii.Bool(false),
@@ -58,14 +69,10 @@ func (s *GuardDutyBasicStrategy) Build(scope constructs.Construct, id string, pr
 					EbsVolumes: jsii.Bool(false),
 				},
 			},
-		},
-
-		// Export findings every 6 hours (cost-effective for basic monitoring)
-		FindingPublishingFrequency: findingFrequency,
+		}
+	}
 
-		// Add resource tags if provided
-		Tags: props.Tags,
-	})
+	detector := awsguardduty.NewCfnDetector(scope, jsii.String(id), detectorProps)
 
 	return detector
 }
@@ -0,0 +1,208 @@
+package guardduty
+
+import (
+	"fmt"
+	"math"
+)
+
+// UsageProfile describes the expected monthly usage of the account and region
+// monitored by the detector, in the dimensions GuardDuty bills. Volumes of
+// disabled features are ignored.
+type UsageProfile struct {
+	// CloudTrailEvents is the number of CloudTrail management events per month.
+	// Analyzed by every DetectorType (foundational monitoring)
+	CloudTrailEvents float64
+
+	// FlowAndDNSLogsGB is the VPC Flow Logs and DNS query logs volume analyzed per month.
+	// Analyzed by every DetectorType (foundational monitoring)
+	FlowAndDNSLogsGB float64
+
+	// S3DataEvents is the number of CloudTrail S3 data events per month (S3_DATA_EVENTS)
+	S3DataEvents float64
+
+	// EKSAuditLogEvents is the number of Kubernetes audit log entries per month (EKS_AUDIT_LOGS)
+	EKSAuditLogEvents float64
+
+	// EKSNodeVCPUs is the average number of vCPUs of the monitored EKS nodes
+	// (EKS_RUNTIME_MONITORING or RUNTIME_MONITORING)
+	EKSNodeVCPUs float64
+
+	// RuntimeVCPUs is the average number of vCPUs of the EC2 instances and Fargate
+	// tasks with the GuardDuty agent (RUNTIME_MONITORING)
+	RuntimeVCPUs float64
+
+	// GBScanned is the EBS volume data scanned per month (EBS_MALWARE_PROTECTION)
+	GBScanned float64
+
+	// RDSCapacityUnits is the average number of provisioned instance vCPUs plus Aurora
+	// Serverless ACUs of the monitored databases (RDS_LOGIN_EVENTS)
+	RDSCapacityUnits float64
+
+	// LambdaNetworkGB is the Lambda network activity analyzed per month (LAMBDA_NETWORK_LOGS)
+	LambdaNetworkGB float64
+
+	// S3MalwareGBScanned is the data scanned by Malware Protection for S3 plans per month
+	S3MalwareGBScanned float64
+
+	// S3MalwareObjects is the number of objects evaluated by Malware Protection for S3 plans per month
+	S3MalwareObjects float64
+}
+
+// GuardDutyPricing holds the unit prices (USD) used by EstimateGuardDutyCost, one per
+// billing dimension of UsageProfile.
+type GuardDutyPricing struct {
+	CloudTrailPerMillionEvents        float64
+	FlowAndDNSLogsPerGB               float64
+	S3DataEventsPerMillionEvents      float64
+	EKSAuditLogsPerMillionEvents      float64
+	EKSRuntimeMonitoringPerVCPU       float64
+	RuntimeMonitoringPerVCPU          float64
+	EBSMalwareProtectionPerGB         float64
+	RDSProtectionPerCapacityUnit      float64
+	LambdaProtectionPerGB             float64
+	S3MalwareProtectionPerGB          float64
+	S3MalwareProtectionPer1000Objects float64
+}
+
+// DefaultGuardDutyPricing returns approximate us-east-1 list prices of the first volume
+// tier. They are placeholders for a first estimate: higher tiers are cheaper, other
+// regions differ and AWS changes prices over time. Override them with the current
+// values from https://aws.amazon.com/guardduty/pricing/ before a FinOps review
+func DefaultGuardDutyPricing() GuardDutyPricing {
+	return GuardDutyPricing{
+		CloudTrailPerMillionEvents:        4.00,
+		FlowAndDNSLogsPerGB:               1.00,
+		S3DataEventsPerMillionEvents:      0.80,
+		EKSAuditLogsPerMillionEvents:      1.60,
+		EKSRuntimeMonitoringPerVCPU:       1.50,
+		RuntimeMonitoringPerVCPU:          1.50,
+		EBSMalwareProtectionPerGB:         0.04,
+		RDSProtectionPerCapacityUnit:      0.21,
+		LambdaProtectionPerGB:             1.00,
+		S3MalwareProtectionPerGB:          0.09,
+		S3MalwareProtectionPer1000Objects: 0.215,
+	}
+}
+
+// FeatureCost is the estimated monthly cost of a feature for one billing dimension.
+type FeatureCost struct {
+	// Feature is the GuardDuty feature name (e.g. "S3_DATA_EVENTS"), "FOUNDATIONAL"
+	// for CloudTrail/VPC Flow/DNS monitoring or "S3_MALWARE_PROTECTION" for the S3 plans.
+	// Features billed on two dimensions have one entry per dimension
+	Feature string
+
+	// Usage is the billed volume, in Unit
+	Usage float64
+
+	// Unit describes how Usage is billed (e.g. "million events", "vCPU-month")
+	Unit string
+
+	// UnitPrice is the price applied per Unit (USD)
+	UnitPrice float64
+
+	// MonthlyCost is Usage * UnitPrice (USD, rounded to cents)
+	MonthlyCost float64
+}
+
+// GuardDutyCostEstimate is the result of EstimateGuardDutyCost.
+type GuardDutyCostEstimate struct {
+	// Features lists the cost of each enabled feature, in the detector feature order
+	Features []FeatureCost
+
+	// MonthlyTotal is the sum of all feature costs (USD)
+	MonthlyTotal float64
+}
+
+// EstimateGuardDutyCost estimates the monthly cost of the detector NewGuardDutyDetector
+// would create for props, given the expected usage. Features are resolved exactly like
+// the strategies do. With FeaturesOnly the estimate matches the deployed configuration;
+// otherwise features the strategy does not manage keep the account defaults and are not
+// included. Member accounts of an organization are billed separately.
+//
+// Example:
+//
+//	pricing := guardduty.DefaultGuardDutyPricing()
+//	pricing.S3DataEventsPerMillionEvents = 0.85 // regional price
+//
+//	estimate := guardduty.EstimateGuardDutyCost(
+//	    guardduty.GuardDutyFactoryProps{
+//	        DetectorType: guardduty.GuardDutyTypeDataProtection,
+//	        FeaturesOnly: jsii.Bool(true),
+//	    },
+//	    guardduty.UsageProfile{
+//	        CloudTrailEvents: 2_000_000,
+//	        FlowAndDNSLogsGB: 50,
+//	        S3DataEvents:     30_000_000,
+//	        LambdaNetworkGB:  5,
+//	    },
+//	    &pricing)
+func EstimateGuardDutyCost(props GuardDutyFactoryProps, usage UsageProfile, pricing *GuardDutyPricing) GuardDutyCostEstimate {
+	switch props.DetectorType {
+	case GuardDutyTypeBasic, GuardDutyTypeDataProtection, GuardDutyTypeCustom:
+	default:
+		panic(fmt.Sprintf("Unsupported GuardDuty detector type: %s. Valid options: BASIC, DATA_PROTECTION, CUSTOM", props.DetectorType))
+	}
+	validateProps(props)
+
+	prices := DefaultGuardDutyPricing()
+	if pricing != nil {
+		prices = *pricing
+	}
+
+	costs := []FeatureCost{
+		featureCost("FOUNDATIONAL", usage.CloudTrailEvents/1e6, "million events", prices.CloudTrailPerMillionEvents),
+		featureCost("FOUNDATIONAL", usage.FlowAndDNSLogsGB, "GB analyzed", prices.FlowAndDNSLogsPerGB),
+	}
+
+	for _, feature := range resolveFeatures(props) {
+		switch feature.name {
+		case "S3_DATA_EVENTS":
+			costs = append(costs, featureCost(feature.name, usage.S3DataEvents/1e6, "million events", prices.S3DataEventsPerMillionEvents))
+		case "EKS_AUDIT_LOGS":
+			costs = append(costs, featureCost(feature.name, usage.EKSAuditLogEvents/1e6, "million events", prices.EKSAuditLogsPerMillionEvents))
+		case "EKS_RUNTIME_MONITORING":
+			costs = append(costs, featureCost(feature.name, usage.EKSNodeVCPUs, "vCPU-month", prices.EKSRuntimeMonitoringPerVCPU))
+		case "EBS_MALWARE_PROTECTION":
+			costs = append(costs, featureCost(feature.name, usage.GBScanned, "GB scanned", prices.EBSMalwareProtectionPerGB))
+		case "RDS_LOGIN_EVENTS":
+			costs = append(costs, featureCost(feature.name, usage.RDSCapacityUnits, "vCPU/ACU-month", prices.RDSProtectionPerCapacityUnit))
+		case "LAMBDA_NETWORK_LOGS":
+			costs = append(costs, featureCost(feature.name, usage.LambdaNetworkGB, "GB analyzed", prices.LambdaProtectionPerGB))
+		case "RUNTIME_MONITORING":
+			// RUNTIME_MONITORING also covers EKS nodes
+			costs = append(costs, featureCost(feature.name, usage.RuntimeVCPUs+usage.EKSNodeVCPUs, "vCPU-month", prices.RuntimeMonitoringPerVCPU))
+		}
+	}
+
+	// Malware Protection for S3 plans are billed per GB scanned and per object evaluated
+	if len(props.S3MalwareProtection) > 0 {
+		costs = append(costs,
+			featureCost("S3_MALWARE_PROTECTION", usage.S3MalwareGBScanned, "GB scanned", prices.S3MalwareProtectionPerGB),
+			featureCost("S3_MALWARE_PROTECTION", usage.S3MalwareObjects/1000, "thousand objects evaluated", prices.S3MalwareProtectionPer1000Objects),
+		)
+	}
+
+	estimate := GuardDutyCostEstimate{Features: costs}
+	for _, cost := range costs {
+		estimate.MonthlyTotal += cost.MonthlyCost
+	}
+	estimate.MonthlyTotal = roundCents(estimate.MonthlyTotal)
+
+	return estimate
+}
+
+// featureCost builds a FeatureCost for a usage billed at a single unit price
+func featureCost(feature string, usage float64, unit string, unitPrice float64) FeatureCost {
+	return FeatureCost{
+		Feature:     feature,
+		Usage:       usage,
+		Unit:        unit,
+		UnitPrice:   unitPrice,
+		MonthlyCost: roundCents(usage * unitPrice),
+	}
+}
+
+// roundCents rounds a USD amount to cents
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,147 @@
+package guardduty
+
+import (
+	"math"
+	"testing"
+)
+
+// testUsage sets every dimension so that disabled features would show up if counted
+var testUsage = UsageProfile{
+	CloudTrailEvents:   2_000_000,
+	FlowAndDNSLogsGB:   50,
+	S3DataEvents:       30_000_000,
+	EKSAuditLogEvents:  10_000_000,
+	EKSNodeVCPUs:       16,
+	RuntimeVCPUs:       8,
+	GBScanned:          200,
+	RDSCapacityUnits:   4,
+	LambdaNetworkGB:    5,
+	S3MalwareGBScanned: 100,
+	S3MalwareObjects:   50_000,
+}
+
+// testPricing uses round prices so expected costs are easy to read
+var testPricing = GuardDutyPricing{
+	CloudTrailPerMillionEvents:        4,
+	FlowAndDNSLogsPerGB:               1,
+	S3DataEventsPerMillionEvents:      1,
+	EKSAuditLogsPerMillionEvents:      2,
+	EKSRuntimeMonitoringPerVCPU:       1,
+	RuntimeMonitoringPerVCPU:          2,
+	EBSMalwareProtectionPerGB:         0.05,
+	RDSProtectionPerCapacityUnit:      0.25,
+	LambdaProtectionPerGB:             1,
+	S3MalwareProtectionPerGB:          0.1,
+	S3MalwareProtectionPer1000Objects: 0.2,
+}
+
+func TestEstimateGuardDutyCost(t *testing.T) {
+	tests := []struct {
+		name  string
+		props GuardDutyFactoryProps
+		want  []FeatureCost
+		total float64
+	}{
+		{
+			name:  "Basic",
+			props: GuardDutyFactoryProps{DetectorType: GuardDutyTypeBasic},
+			want: []FeatureCost{
+				{Feature: "FOUNDATIONAL", Usage: 2, Unit: "million events", UnitPrice: 4, MonthlyCost: 8},
+				{Feature: "FOUNDATIONAL", Usage: 50, Unit: "GB analyzed", UnitPrice: 1, MonthlyCost: 50},
+			},
+			total: 58,
+		},
+		{
+			name:  "DataProtection",
+			props: GuardDutyFactoryProps{DetectorType: GuardDutyTypeDataProtection},
+			want: []FeatureCost{
+				{Feature: "FOUNDATIONAL", Usage: 2, Unit: "million events", UnitPrice: 4, MonthlyCost: 8},
+				{Feature: "FOUNDATIONAL", Usage: 50, Unit: "GB analyzed", UnitPrice: 1, MonthlyCost: 50},
+				{Feature: "S3_DATA_EVENTS", Usage: 30, Unit: "million events", UnitPrice: 1, MonthlyCost: 30},
+				{Feature: "EKS_AUDIT_LOGS", Usage: 10, Unit: "million events", UnitPrice: 2, MonthlyCost: 20},
+				{Feature: "EBS_MALWARE_PROTECTION", Usage: 200, Unit: "GB scanned", UnitPrice: 0.05, MonthlyCost: 10},
+				{Feature: "RDS_LOGIN_EVENTS", Usage: 4, Unit: "vCPU/ACU-month", UnitPrice: 0.25, MonthlyCost: 1},
+				{Feature: "LAMBDA_NETWORK_LOGS", Usage: 5, Unit: "GB analyzed", UnitPrice: 1, MonthlyCost: 5},
+			},
+			total: 124,
+		},
+		{
+			name: "Custom with runtime monitoring and S3 malware protection",
+			props: GuardDutyFactoryProps{
+				DetectorType:            GuardDutyTypeCustom,
+				EnableS3Protection:      boolPtr(true),
+				EnableMalwareProtection: boolPtr(false),
+				EnableRuntimeMonitoring: boolPtr(true),
+				S3MalwareProtection:     []S3MalwareProtectionOptions{{}},
+			},
+			want: []FeatureCost{
+				{Feature: "FOUNDATIONAL", Usage: 2, Unit: "million events", UnitPrice: 4, MonthlyCost: 8},
+				{Feature: "FOUNDATIONAL", Usage: 50, Unit: "GB analyzed", UnitPrice: 1, MonthlyCost: 50},
+				{Feature: "S3_DATA_EVENTS", Usage: 30, Unit: "million events", UnitPrice: 1, MonthlyCost: 30},
+				// RUNTIME_MONITORING covers EC2/Fargate and EKS vCPUs
+				{Feature: "RUNTIME_MONITORING", Usage: 24, Unit: "vCPU-month", UnitPrice: 2, MonthlyCost: 48},
+				{Feature: "S3_MALWARE_PROTECTION", Usage: 100, Unit: "GB scanned", UnitPrice: 0.1, MonthlyCost: 10},
+				{Feature: "S3_MALWARE_PROTECTION", Usage: 50, Unit: "thousand objects evaluated", UnitPrice: 0.2, MonthlyCost: 10},
+			},
+			total: 156,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pricing := testPricing
+			estimate := EstimateGuardDutyCost(tt.props, testUsage, &pricing)
+
+			if len(estimate.Features) != len(tt.want) {
+				t.Fatalf("got %d cost entries, want %d: %+v", len(estimate.Features), len(tt.want), estimate.Features)
+			}
+
+			for i, want := range tt.want {
+				got := estimate.Features[i]
+				if got.Feature != want.Feature || got.Unit != want.Unit || !centsEqual(got.Usage, want.Usage) ||
+					!centsEqual(got.UnitPrice, want.UnitPrice) || !centsEqual(got.MonthlyCost, want.MonthlyCost) {
+					t.Errorf("entry %d = %+v, want %+v", i, got, want)
+				}
+
+				// Every entry honors the MonthlyCost = Usage * UnitPrice contract
+				if !centsEqual(got.MonthlyCost, got.Usage*got.UnitPrice) {
+					t.Errorf("entry %d MonthlyCost %.2f != Usage*UnitPrice %.2f", i, got.MonthlyCost, got.Usage*got.UnitPrice)
+				}
+			}
+
+			if !centsEqual(estimate.MonthlyTotal, tt.total) {
+				t.Errorf("MonthlyTotal = %.2f, want %.2f", estimate.MonthlyTotal, tt.total)
+			}
+		})
+	}
+}
+
+func TestEstimateGuardDutyCostDefaultPricing(t *testing.T) {
+	estimate := EstimateGuardDutyCost(GuardDutyFactoryProps{DetectorType: GuardDutyTypeBasic}, testUsage, nil)
+
+	want := DefaultGuardDutyPricing().CloudTrailPerMillionEvents
+	if estimate.Features[0].UnitPrice != want {
+		t.Errorf("nil pricing used UnitPrice %.2f, want default %.2f", estimate.Features[0].UnitPrice, want)
+	}
+}
+
+func TestEstimateGuardDutyCostInvalidProps(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for agent management without runtime monitoring")
+		}
+	}()
+
+	EstimateGuardDutyCost(GuardDutyFactoryProps{
+		DetectorType:             GuardDutyTypeCustom,
+		EnableEC2AgentManagement: boolPtr(true),
+	}, testUsage, nil)
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+func centsEqual(a, b float64) bool {
+	return math.Abs(a-b) < 0.005
+}
@@ -44,7 +44,39 @@ func (s *GuardDutyCustomStrategy) Build(scope constructs.Construct, id string, p
 		findingFrequency = jsii.String("SIX_HOURS")
 	}
 
-	// Build data sources configuration based on user input
+	// Build features configuration from the feature flags (shared with organization configuration).
+	// Features-only mode also disables every feature not enabled by a flag (no DataSources block)
+	var disabledFeatures []string
+	if isEnabled(props.FeaturesOnly) {
+		disabledFeatures = resolveDisabledFeatures(props)
+	}
+	features := detectorFeatures(resolveFeatures(props), disabledFeatures)
+
+	// Create detector with custom configuration
+	detectorProps := &awsguardduty.CfnDetectorProps{
+		Enable:                     jsii.Bool(true),
+		FindingPublishingFrequency: findingFrequency,
+		Tags:                       props.Tags,
+	}
+
+	// Legacy DataSources block (skipped in Features-only mode)
+	if !isEnabled(props.FeaturesOnly) {
+		detectorProps.DataSources = legacyDataSources(props)
+	}
+
+	// Only set Features if at least one feature was configured
+	if len(*features) > 0 {
+		detectorProps.Features = features
+	}
+
+	detector := awsguardduty.NewCfnDetector(scope, jsii.String(id), detectorProps)
+
+	return detector
+}
+
+// legacyDataSources builds the deprecated DataSources block from the feature flags.
+// Returns nil when no data source was configured
+func legacyDataSources(props GuardDutyFactoryProps) *awsguardduty.CfnDetector_CFNDataSourceConfigurationsProperty {
 	dataSourcesConfig := &awsguardduty.CfnDetector_CFNDataSourceConfigurationsProperty{}
 
 	// Configure S3 protection if requested
@@ -72,112 +104,10 @@ func (s *GuardDutyCustomStrategy) Build(scope constructs.Construct, id string, p
 		}
 	}
 
-	// Build features configuration based on user input
-	features := &[]interface{}{}
-
-	// Add S3 data events if enabled
-	if props.EnableS3Protection != nil && *props.EnableS3Protection {
-		*features = append(*features, &awsguardduty.CfnDetector_CFNFeatureConfigurationProperty{
-			Name:   jsii.String("S3_DATA_EVENTS"),
-			Status: jsii.String("ENABLED"),
-		})
-	}
-
-	// Add EKS features if enabled
-	if props.EnableEKSProtection != nil && *props.EnableEKSProtection {
-		*features = append(*features, &awsguardduty.CfnDetector_CFNFeatureConfigurationProperty{
-			Name:   jsii.String("EKS_AUDIT_LOGS"),
-			Status: jsii.String("ENABLED"),
-		})
-
-		// Add EKS runtime monitoring if specified
-		if props.EnableEKSRuntimeMonitoring != nil && *props.EnableEKSRuntimeMonitoring {
-			*features = append(*features, &awsguardduty.CfnDetector_CFNFeatureConfigurationProperty{
-				Name:   jsii.String("EKS_RUNTIME_MONITORING"),
-				Status: jsii.String("ENABLED"),
-				AdditionalConfiguration: &[]interface{}{
-					&awsguardduty.CfnDetector_CFNFeatureAdditionalConfigurationProperty{
-						Name:   jsii.String("EKS_ADDON_MANAGEMENT"),
-						Status: jsii.String("ENABLED"),
-					},
-				},
-			})
-		}
-	}
-
-	// Add EBS malware protection if enabled
-	if props.EnableMalwareProtection != nil && *props.EnableMalwareProtection {
-		*features = append(*features, &awsguardduty.CfnDetector_CFNFeatureConfigurationProperty{
-			Name:   jsii.String("EBS_MALWARE_PROTECTION"),
-			Status: jsii.String("ENABLED"),
-		})
-	}
-
-	// Add RDS login events if enabled
-	if props.EnableRDSProtection != nil && *props.EnableRDSProtection {
-		*features = append(*features, &awsguardduty.CfnDetector_CFNFeatureConfigurationProperty{
-			Name:   jsii.String("RDS_LOGIN_EVENTS"),
-			Status: jsii.String("ENABLED"),
-		})
-	}
-
-	// Add Lambda network logs if enabled
-	if props.EnableLambdaProtection != nil && *props.EnableLambdaProtection {
-		*features = append(*features, &awsguardduty.CfnDetector_CFNFeatureConfigurationProperty{
-			Name:   jsii.String("LAMBDA_NETWORK_LOGS"),
-			Status: jsii.String("ENABLED"),
-		})
-	}
-
-	// Add Runtime Monitoring if enabled
-	if props.EnableRuntimeMonitoring != nil && *props.EnableRuntimeMonitoring {
-		runtimeConfig := &awsguardduty.CfnDetector_CFNFeatureConfigurationProperty{
-			Name:   jsii.String("RUNTIME_MONITORING"),
-			Status: jsii.String("ENABLED"),
-		}
-
-		// Add agent management configurations if specified
-		additionalConfig := &[]interface{}{}
-
-		if props.EnableEC2AgentManagement != nil && *props.EnableEC2AgentManagement {
-			*additionalConfig = append(*additionalConfig, &awsguardduty.CfnDetector_CFNFeatureAdditionalConfigurationProperty{
-				Name:   jsii.String("EC2_AGENT_MANAGEMENT"),
-				Status: jsii.String("ENABLED"),
-			})
-		}
-
-		if props.EnableFargateAgentManagement != nil && *props.EnableFargateAgentManagement {
-			*additionalConfig = append(*additionalConfig, &awsguardduty.CfnDetector_CFNFeatureAdditionalConfigurationProperty{
-				Name:   jsii.String("ECS_FARGATE_AGENT_MANAGEMENT"),
-				Status: jsii.String("ENABLED"),
-			})
-		}
-
-		if len(*additionalConfig) > 0 {
-			runtimeConfig.AdditionalConfiguration = additionalConfig
-		}
-
-		*features = append(*features, runtimeConfig)
-	}
-
-	// Create detector with custom configuration
-	detectorProps := &awsguardduty.CfnDetectorProps{
-		Enable:                     jsii.Bool(true),
-		FindingPublishingFrequency: findingFrequency,
-		Tags:                       props.Tags,
-	}
-
 	// Only set DataSources if at least one data source was configured
-	if dataSourcesConfig.S3Logs != nil || dataSourcesConfig.Kubernetes != nil || dataSourcesConfig.MalwareProtection != nil {
-		detectorProps.DataSources = dataSourcesConfig
-	}
-
-	// Only set Features if at least one feature was configured
-	if len(*features) > 0 {
-		detectorProps.Features = features
+	if dataSourcesConfig.S3Logs == nil && dataSourcesConfig.Kubernetes == nil && dataSourcesConfig.MalwareProtection == nil {
+		return nil
 	}
 
-	detector := awsguardduty.NewCfnDetector(scope, jsii.String(id), detectorProps)
-
-	return detector
+	return dataSourcesConfig
 }
@@ -43,45 +43,19 @@ func (s *GuardDutyDataProtectionStrategy) Build(scope constructs.Construct, id s
 		findingFrequency = jsii.String("FIFTEEN_MINUTES")
 	}
 
+	// Features-only mode also disables the features this strategy leaves out (runtime monitoring)
+	var disabledFeatures []string
+	if isEnabled(props.FeaturesOnly) {
+		disabledFeatures = resolveDisabledFeatures(props)
+	}
+
 	// Create detector with comprehensive protection enabled
 	detector := awsguardduty.NewCfnDetector(scope, jsii.String(id), &awsguardduty.CfnDetectorProps{
 		Enable: jsii.Bool(true),
 
 		// Enable advanced protection features using the Features API
-		Features: &[]interface{}{
-			// S3 data events monitoring (detects unusual S3 access patterns)
-			&awsguardduty.CfnDetector_CFNFeatureConfigurationProperty{
-				Name:   jsii.String("S3_DATA_EVENTS"),
-				Status: jsii.String("ENABLED"),
-			},
-
-			// EKS audit logs (Kubernetes API monitoring without runtime agents)
-			&awsguardduty.CfnDetector_CFNFeatureConfigurationProperty{
-				Name:   jsii.String("EKS_AUDIT_LOGS"),
-				Status: jsii.String("ENABLED"),
-			},
-
-			// EBS malware protection (agentless snapshot scanning)
-			&awsguardduty.CfnDetector_CFNFeatureConfigurationProperty{
-				Name:   jsii.String("EBS_MALWARE_PROTECTION"),
-				Status: jsii.String("ENABLED"),
-			},
-
-			// RDS login activity monitoring (detects brute force, anomalous logins)
-			&awsguardduty.CfnDetector_CFNFeatureConfigurationProperty{
-				Name:   jsii.String("RDS_LOGIN_EVENTS"),
-				Status: jsii.String("ENABLED"),
-			},
-
-			// Lambda network activity monitoring (detects C2 communication)
-			&awsguardduty.CfnDetector_CFNFeatureConfigurationProperty{
-				Name:   jsii.String("LAMBDA_NETWORK_LOGS"),
-				Status: jsii.String("ENABLED"),
-			},
-
-			// Note: Runtime monitoring (EC2_RUNTIME_MONITORING, EKS_RUNTIME_MONITORING)
-			// excluded to avoid conflicts. Use specific strategies when needed.
-		},
+		// (S3, EKS audit logs, EBS malware, RDS, Lambda - see dataProtectionFeatures)
+		Features: detectorFeatures(resolveFeatures(props), disabledFeatures),
 
 		// Rapid finding publication for quick incident response
 		FindingPublishingFrequency: findingFrequency,
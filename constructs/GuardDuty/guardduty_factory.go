@@ -43,32 +43,32 @@ type GuardDutyFactoryProps struct {
 	// --- Custom Strategy Options (only used when DetectorType = GuardDutyTypeCustom) ---
 
 	// EnableS3Protection monitors S3 data access patterns and bucket policy changes.
-	// Optional (Custom strategy only). Cost: ~$0.50 per million CloudTrail data events
+	// Optional (Custom strategy only). Cost: ~$0.80 per million S3 data events
 	EnableS3Protection *bool
 
 	// EnableEKSProtection monitors Kubernetes audit logs for container threats.
-	// Optional (Custom strategy only). Cost: ~$0.50 per million audit log entries
+	// Optional (Custom strategy only). Cost: ~$1.60 per million audit log entries
 	EnableEKSProtection *bool
 
 	// EnableEKSRuntimeMonitoring enables deep runtime visibility for EKS workloads.
 	// Optional (Custom strategy only). Requires EnableEKSProtection=true
-	// Cost: ~$1.20 per node per month
+	// Cost: ~$1.50 per node vCPU per month
 	EnableEKSRuntimeMonitoring *bool
 
 	// EnableMalwareProtection scans EBS volumes attached to EC2 instances.
-	// Optional (Custom strategy only). Cost: ~$1.00 per GB scanned
+	// Optional (Custom strategy only). Cost: ~$0.04 per GB scanned
 	EnableMalwareProtection *bool
 
 	// EnableRDSProtection detects anomalous database login activity.
-	// Optional (Custom strategy only). Cost: ~$0.10 per million login events
+	// Optional (Custom strategy only). Cost: ~$0.21 per instance vCPU or Aurora Serverless ACU per month
 	EnableRDSProtection *bool
 
 	// EnableLambdaProtection monitors Lambda function network activity for C2 communication.
-	// Optional (Custom strategy only). Cost: ~$0.40 per million invocations
+	// Optional (Custom strategy only). Cost: ~$1.00 per GB of network activity analyzed
 	EnableLambdaProtection *bool
 
 	// EnableRuntimeMonitoring enables EC2 and Fargate runtime threat detection.
-	// Optional (Custom strategy only). Cost: ~$1.15 per node per month
+	// Optional (Custom strategy only). Cost: ~$1.50 per vCPU per month
 	EnableRuntimeMonitoring *bool
 
 	// EnableEC2AgentManagement automatically deploys GuardDuty security agent to EC2 instances.
@@ -78,6 +78,36 @@ type GuardDutyFactoryProps struct {
 	// EnableFargateAgentManagement automatically deploys GuardDuty agent to ECS Fargate tasks.
 	// Optional (Custom strategy only). Requires EnableRuntimeMonitoring=true
 	EnableFargateAgentManagement *bool
+
+	// --- Options available for every DetectorType ---
+
+	// FeaturesOnly configures the detector only through the Features list, without the
+	// deprecated DataSources block (mixing both is rejected by CloudFormation in some regions).
+	// Every feature the strategy does not enable is listed with status DISABLED, so the
+	// detector never keeps paid features turned on by the account defaults.
+	// Optional. Default: false (DataSources + Features, as in previous versions)
+	FeaturesOnly *bool
+
+	// S3MalwareProtection creates a Malware Protection for S3 plan per bucket.
+	// Optional. Cost: ~$0.09 per GB scanned + $0.215 per 1,000 objects evaluated
+	S3MalwareProtection []S3MalwareProtectionOptions
+
+	// Organization designates the delegated administrator and/or auto-enables member
+	// accounts with the same features as this detector (DetectorType and Enable* flags).
+	// Optional. Cost: every member account is billed for its own usage
+	Organization *GuardDutyOrganizationOptions
+
+	// SuppressionRules auto-archive known-benign findings (CfnFilter), evaluated in order.
+	// Optional. Example: port probes against hosts tagged Role=vulnerability-scanner
+	SuppressionRules []SuppressionRule
+
+	// TrustedIPLists are files of addresses GuardDuty never reports (e.g. own scanners),
+	// uploaded as S3 assets. Optional. Maximum 1 per detector
+	TrustedIPLists []IPListFile
+
+	// ThreatIntelSets are files of known malicious addresses that generate findings,
+	// uploaded as S3 assets. Optional. Maximum 6 per detector
+	ThreatIntelSets []IPListFile
 }
 
 // NewGuardDutyDetector creates a GuardDuty threat detection detector using the Factory pattern.
@@ -114,6 +144,17 @@ type GuardDutyFactoryProps struct {
 //	        EnableEKSProtection: jsii.Bool(true),
 //	        EnableEKSRuntimeMonitoring: jsii.Bool(true),
 //	        FindingPublishingFrequency: jsii.String("ONE_HOUR"),
+//	        FeaturesOnly: jsii.Bool(true),
+//	    })
+//
+// Example (Organization - delegated administrator account):
+//
+//	detector := guardduty.NewGuardDutyDetector(stack, "OrgDetector",
+//	    guardduty.GuardDutyFactoryProps{
+//	        DetectorType: guardduty.GuardDutyTypeDataProtection,
+//	        Organization: &guardduty.GuardDutyOrganizationOptions{
+//	            AutoEnableMembers: guardduty.OrganizationAutoEnableAll,
+//	        },
 //	    })
 func NewGuardDutyDetector(scope constructs.Construct, id string, props GuardDutyFactoryProps) awsguardduty.CfnDetector {
 
@@ -134,6 +175,26 @@ func NewGuardDutyDetector(scope constructs.Construct, id string, props GuardDuty
 		panic(fmt.Sprintf("Unsupported GuardDuty detector type: %s. Valid options: BASIC, DATA_PROTECTION, CUSTOM", props.DetectorType))
 	}
 
+	// Fail synth on invalid combinations instead of silently dropping options
+	validateProps(props)
+
 	// Execute strategy to build detector
-	return strategy.Build(scope, id, props)
+	detector := strategy.Build(scope, id, props)
+
+	// Malware Protection for S3 plans are independent of the detector features
+	for i, options := range props.S3MalwareProtection {
+		NewS3MalwareProtectionPlan(scope, fmt.Sprintf("%sS3MalwareProtection%d", id, i), options)
+	}
+
+	// Finding tuning: suppression filters, trusted IPs and threat intelligence
+	newSuppressionFilters(scope, id, detector, props.SuppressionRules)
+	newTrustedIPLists(scope, id, detector, props.TrustedIPLists)
+	newThreatIntelSets(scope, id, detector, props.ThreatIntelSets)
+
+	// Organization administrator mode (delegated admin and member auto-enable)
+	if props.Organization != nil {
+		newOrganizationConfiguration(scope, id, detector, props)
+	}
+
+	return detector
 }
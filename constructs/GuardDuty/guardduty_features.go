@@ -0,0 +1,179 @@
+package guardduty
+
+import (
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsguardduty"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// detectorFeature is a GuardDuty feature enabled by the detector type or the feature flags,
+// with the names of its enabled additional configurations.
+type detectorFeature struct {
+	name                    string
+	additionalConfiguration []string
+}
+
+// dataProtectionFeatures are the features enabled by GuardDutyTypeDataProtection.
+// Runtime monitoring (RUNTIME_MONITORING, EKS_RUNTIME_MONITORING) is excluded: it
+// requires agents and is only available through the Custom strategy
+var dataProtectionFeatures = []detectorFeature{
+	// S3 data events monitoring (detects unusual S3 access patterns)
+	{name: "S3_DATA_EVENTS"},
+
+	// EKS audit logs (Kubernetes API monitoring without runtime agents)
+	{name: "EKS_AUDIT_LOGS"},
+
+	// EBS malware protection (agentless snapshot scanning)
+	{name: "EBS_MALWARE_PROTECTION"},
+
+	// RDS login activity monitoring (detects brute force, anomalous logins)
+	{name: "RDS_LOGIN_EVENTS"},
+
+	// Lambda network activity monitoring (detects C2 communication)
+	{name: "LAMBDA_NETWORK_LOGS"},
+}
+
+// managedFeatures are the features disabled when not enabled (Features-only mode and
+// organization configuration). EKS_RUNTIME_MONITORING is only sent when enabled: AWS
+// recommends configuring RUNTIME_MONITORING instead and rejects conflicting settings for both
+var managedFeatures = []string{
+	"S3_DATA_EVENTS",
+	"EKS_AUDIT_LOGS",
+	"EBS_MALWARE_PROTECTION",
+	"RDS_LOGIN_EVENTS",
+	"LAMBDA_NETWORK_LOGS",
+	"RUNTIME_MONITORING",
+}
+
+// resolveFeatures returns the features enabled for props, in a stable order.
+// It is the single source of truth for the detector strategies and every
+// other consumer of the feature flags (e.g. organization configuration).
+func resolveFeatures(props GuardDutyFactoryProps) []detectorFeature {
+	switch props.DetectorType {
+	case GuardDutyTypeDataProtection:
+		return dataProtectionFeatures
+
+	case GuardDutyTypeCustom:
+		return resolveCustomFeatures(props)
+
+	default:
+		// Basic: foundational data sources only (always on, not features)
+		return []detectorFeature{}
+	}
+}
+
+// resolveCustomFeatures maps the Custom strategy flags to features
+func resolveCustomFeatures(props GuardDutyFactoryProps) []detectorFeature {
+	features := make([]detectorFeature, 0)
+
+	// S3 data events
+	if isEnabled(props.EnableS3Protection) {
+		features = append(features, detectorFeature{name: "S3_DATA_EVENTS"})
+	}
+
+	// EKS audit logs, and EKS runtime monitoring with managed add-on
+	if isEnabled(props.EnableEKSProtection) {
+		features = append(features, detectorFeature{name: "EKS_AUDIT_LOGS"})
+
+		if isEnabled(props.EnableEKSRuntimeMonitoring) {
+			features = append(features, detectorFeature{
+				name:                    "EKS_RUNTIME_MONITORING",
+				additionalConfiguration: []string{"EKS_ADDON_MANAGEMENT"},
+			})
+		}
+	}
+
+	// EBS malware protection
+	if isEnabled(props.EnableMalwareProtection) {
+		features = append(features, detectorFeature{name: "EBS_MALWARE_PROTECTION"})
+	}
+
+	// RDS login events
+	if isEnabled(props.EnableRDSProtection) {
+		features = append(features, detectorFeature{name: "RDS_LOGIN_EVENTS"})
+	}
+
+	// Lambda network logs
+	if isEnabled(props.EnableLambdaProtection) {
+		features = append(features, detectorFeature{name: "LAMBDA_NETWORK_LOGS"})
+	}
+
+	// Runtime monitoring with optional agent management
+	if isEnabled(props.EnableRuntimeMonitoring) {
+		runtime := detectorFeature{name: "RUNTIME_MONITORING"}
+
+		if isEnabled(props.EnableEC2AgentManagement) {
+			runtime.additionalConfiguration = append(runtime.additionalConfiguration, "EC2_AGENT_MANAGEMENT")
+		}
+		if isEnabled(props.EnableFargateAgentManagement) {
+			runtime.additionalConfiguration = append(runtime.additionalConfiguration, "ECS_FARGATE_AGENT_MANAGEMENT")
+		}
+
+		features = append(features, runtime)
+	}
+
+	return features
+}
+
+// resolveDisabledFeatures returns every known feature that resolveFeatures does not
+// enable. Only Features-only mode needs them: there is no DataSources block, and
+// features left out of the list keep the account default (some are on for new detectors)
+func resolveDisabledFeatures(props GuardDutyFactoryProps) []string {
+	enabled := make(map[string]bool)
+	for _, feature := range resolveFeatures(props) {
+		enabled[feature.name] = true
+	}
+
+	disabled := make([]string, 0, len(managedFeatures))
+	for _, name := range managedFeatures {
+		if enabled[name] {
+			continue
+		}
+		// EKS_RUNTIME_MONITORING is enabled: a DISABLED RUNTIME_MONITORING would conflict with it
+		if name == "RUNTIME_MONITORING" && enabled["EKS_RUNTIME_MONITORING"] {
+			continue
+		}
+		disabled = append(disabled, name)
+	}
+
+	return disabled
+}
+
+// detectorFeatures converts resolved features to the CfnDetector Features property,
+// adding the disabled feature names with status DISABLED
+func detectorFeatures(features []detectorFeature, disabled []string) *[]interface{} {
+	result := make([]interface{}, 0, len(features)+len(disabled))
+
+	for _, feature := range features {
+		config := &awsguardduty.CfnDetector_CFNFeatureConfigurationProperty{
+			Name:   jsii.String(feature.name),
+			Status: jsii.String("ENABLED"),
+		}
+
+		if len(feature.additionalConfiguration) > 0 {
+			additionalConfig := make([]interface{}, len(feature.additionalConfiguration))
+			for i, name := range feature.additionalConfiguration {
+				additionalConfig[i] = &awsguardduty.CfnDetector_CFNFeatureAdditionalConfigurationProperty{
+					Name:   jsii.String(name),
+					Status: jsii.String("ENABLED"),
+				}
+			}
+			config.AdditionalConfiguration = &additionalConfig
+		}
+
+		result = append(result, config)
+	}
+
+	for _, name := range disabled {
+		result = append(result, &awsguardduty.CfnDetector_CFNFeatureConfigurationProperty{
+			Name:   jsii.String(name),
+			Status: jsii.String("DISABLED"),
+		})
+	}
+
+	return &result
+}
+
+// isEnabled reports whether an optional flag is set to true
+func isEnabled(flag *bool) bool {
+	return flag != nil && *flag
+}
@@ -0,0 +1,325 @@
+package guardduty
+
+import (
+	"fmt"
+
+	s3 "cdk-library/constructs/S3"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsevents"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awseventstargets"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awskinesisfirehose"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awslambda"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awss3"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awssns"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awssnssubscriptions"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awssqs"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// SeverityBand groups GuardDuty finding severities as shown in the console.
+type SeverityBand string
+
+const (
+	// SeverityLow matches findings with severity 1.0-3.9 (blocked or suspicious activity with no impact)
+	SeverityLow SeverityBand = "LOW"
+
+	// SeverityMedium matches findings with severity 4.0-6.9 (activity that deviates from normal behavior)
+	SeverityMedium SeverityBand = "MEDIUM"
+
+	// SeverityHigh matches findings with severity 7.0-8.9 (resource compromised, act immediately)
+	SeverityHigh SeverityBand = "HIGH"
+
+	// SeverityCritical matches findings with severity 9.0-10.0 (attack sequences in progress)
+	SeverityCritical SeverityBand = "CRITICAL"
+)
+
+// NotificationFormat defines the message published to SNS targets.
+type NotificationFormat string
+
+const (
+	// NotificationFormatText publishes a readable summary (email subscriptions)
+	NotificationFormatText NotificationFormat = "TEXT"
+
+	// NotificationFormatChatbot publishes an AWS Chatbot custom notification (Slack / Microsoft Teams channels)
+	NotificationFormatChatbot NotificationFormat = "CHATBOT"
+
+	// NotificationFormatJSON publishes the full EventBridge event (automation subscribers)
+	NotificationFormatJSON NotificationFormat = "JSON"
+)
+
+// FindingsRoute sends matching findings to one or more targets.
+type FindingsRoute struct {
+	// Name identifies the route in construct ids and rule descriptions.
+	// Required. Must be unique within the routing construct
+	Name string
+
+	// SeverityBands limits the route to these bands.
+	// Optional. Default: every severity
+	SeverityBands []SeverityBand
+
+	// FindingTypes limits the route to finding types starting with these prefixes
+	// (e.g. "CryptoCurrency:", "UnauthorizedAccess:IAMUser/").
+	// Optional. Default: every finding type
+	FindingTypes []string
+
+	// Topic receives the findings formatted with NotificationFormat.
+	// Optional. Created when EmailAddresses is set and Topic is nil
+	Topic awssns.ITopic
+
+	// EmailAddresses subscribes these addresses to the route topic.
+	// Optional. Subscriptions must be confirmed from the email AWS sends
+	EmailAddresses []string
+
+	// NotificationFormat of the SNS message.
+	// Optional. Default: NotificationFormatText
+	NotificationFormat NotificationFormat
+
+	// Queue receives the full finding event (e.g. a SIEM or ticketing consumer).
+	// Optional
+	Queue awssqs.IQueue
+
+	// Function is invoked with the full finding event (automated remediation).
+	// Optional
+	Function awslambda.IFunction
+}
+
+// GuardDutyFindingsRoutingProps defines the routes and the findings archive.
+type GuardDutyFindingsRoutingProps struct {
+	// Routes evaluated independently: a finding matching several routes is sent to all of them.
+	// Optional. Only the archive is created when empty
+	Routes []FindingsRoute
+
+	// ArchiveBucketName is the globally unique name of the findings archive bucket.
+	// Required. Built with the S3 BucketTypeEnterprise strategy (KMS, Object Lock, 7-year retention)
+	ArchiveBucketName string
+
+	// ArchivePrefix is the key prefix of archived findings.
+	// Optional. Default: "guardduty-findings/"
+	ArchivePrefix *string
+}
+
+// GuardDutyFindingsRouting exposes the resources created by NewGuardDutyFindingsRouting.
+type GuardDutyFindingsRouting struct {
+	// Rules created for each route, in the same order as props.Routes
+	Rules []awsevents.Rule
+
+	// Topics created for routes with EmailAddresses and no Topic, by route name
+	Topics map[string]awssns.Topic
+
+	// ArchiveBucket stores every finding delivered by ArchiveStream
+	ArchiveBucket awss3.Bucket
+
+	// ArchiveStream is the Firehose stream that batches findings into ArchiveBucket
+	ArchiveStream awskinesisfirehose.DeliveryStream
+}
+
+// severityBandRanges maps each band to its EventBridge numeric matcher
+var severityBandRanges = map[SeverityBand][]interface{}{
+	SeverityLow:      {">=", 1, "<", 4},
+	SeverityMedium:   {">=", 4, "<", 7},
+	SeverityHigh:     {">=", 7, "<", 9},
+	SeverityCritical: {">=", 9},
+}
+
+// NewGuardDutyFindingsRouting routes GuardDuty findings to SNS, SQS or Lambda through
+// EventBridge rules filtered by severity band and finding type, and archives every
+// finding to an encrypted S3 bucket through Firehose.
+//
+// Findings reach EventBridge according to the detector FindingPublishingFrequency
+// (new findings are always published within 5 minutes; the frequency applies to updates).
+//
+// Example:
+//
+//	routing := guardduty.NewGuardDutyFindingsRouting(stack, "Findings",
+//	    guardduty.GuardDutyFindingsRoutingProps{
+//	        ArchiveBucketName: "acme-guardduty-findings-prod",
+//	        Routes: []guardduty.FindingsRoute{
+//	            {
+//	                Name:           "Critical",
+//	                SeverityBands:  []guardduty.SeverityBand{guardduty.SeverityHigh, guardduty.SeverityCritical},
+//	                EmailAddresses: []string{"security@acme.com"},
+//	            },
+//	            {
+//	                Name:         "Crypto",
+//	                FindingTypes: []string{"CryptoCurrency:"},
+//	                Function:     remediationLambda,
+//	            },
+//	        },
+//	    })
+func NewGuardDutyFindingsRouting(scope constructs.Construct, id string, props GuardDutyFindingsRoutingProps) GuardDutyFindingsRouting {
+	if props.ArchiveBucketName == "" {
+		panic("GuardDutyFindingsRoutingProps.ArchiveBucketName is required")
+	}
+
+	routing := GuardDutyFindingsRouting{
+		Rules:  make([]awsevents.Rule, 0, len(props.Routes)),
+		Topics: make(map[string]awssns.Topic),
+	}
+
+	// Routes
+	routeNames := make(map[string]bool)
+	for _, route := range props.Routes {
+		if route.Name == "" {
+			panic("FindingsRoute.Name is required")
+		}
+		if routeNames[route.Name] {
+			panic(fmt.Sprintf("FindingsRoute name %s is used more than once", route.Name))
+		}
+		routeNames[route.Name] = true
+
+		rule, topic := newFindingsRouteRule(scope, id, route)
+		routing.Rules = append(routing.Rules, rule)
+		if topic != nil {
+			routing.Topics[route.Name] = topic
+		}
+	}
+
+	// Archive: every finding, regardless of routes
+	archivePrefix := props.ArchivePrefix
+	if archivePrefix == nil {
+		archivePrefix = jsii.String("guardduty-findings/")
+	}
+
+	routing.ArchiveBucket = s3.NewSimpleStorageServiceFactory(scope, id+"ArchiveBucket", s3.SimpleStorageServiceFactoryProps{
+		BucketType: s3.BucketTypeEnterprise,
+		BucketName: props.ArchiveBucketName,
+	})
+
+	routing.ArchiveStream = awskinesisfirehose.NewDeliveryStream(scope, jsii.String(id+"ArchiveStream"), &awskinesisfirehose.DeliveryStreamProps{
+		Destination: awskinesisfirehose.NewS3Bucket(routing.ArchiveBucket, &awskinesisfirehose.S3BucketProps{
+			// Partitioned by date for Athena queries
+			DataOutputPrefix:  jsii.String(*archivePrefix + "year=!{timestamp:yyyy}/month=!{timestamp:MM}/day=!{timestamp:dd}/"),
+			ErrorOutputPrefix: jsii.String(*archivePrefix + "errors/!{firehose:error-output-type}/"),
+		}),
+	})
+
+	archiveRule := awsevents.NewRule(scope, jsii.String(id+"ArchiveRule"), &awsevents.RuleProps{
+		Description:  jsii.String("Archives every GuardDuty finding to " + props.ArchiveBucketName),
+		EventPattern: findingsEventPattern(nil, nil),
+	})
+	archiveRule.AddTarget(awseventstargets.NewFirehoseDeliveryStream(routing.ArchiveStream, nil))
+
+	return routing
+}
+
+// newFindingsRouteRule creates the EventBridge rule of a route and its targets.
+// Returns the topic created for EmailAddresses, or nil
+func newFindingsRouteRule(scope constructs.Construct, id string, route FindingsRoute) (awsevents.Rule, awssns.Topic) {
+	ruleID := id + route.Name
+
+	topic := route.Topic
+	var createdTopic awssns.Topic
+	if topic == nil && len(route.EmailAddresses) > 0 {
+		createdTopic = awssns.NewTopic(scope, jsii.String(ruleID+"Topic"), &awssns.TopicProps{
+			DisplayName: jsii.String("GuardDuty " + route.Name),
+		})
+		topic = createdTopic
+	}
+
+	if topic == nil && route.Queue == nil && route.Function == nil {
+		panic(fmt.Sprintf("FindingsRoute %s requires at least one target (Topic, EmailAddresses, Queue or Function)", route.Name))
+	}
+
+	if topic != nil {
+		for _, email := range route.EmailAddresses {
+			topic.AddSubscription(awssnssubscriptions.NewEmailSubscription(jsii.String(email), nil))
+		}
+	}
+
+	rule := awsevents.NewRule(scope, jsii.String(ruleID+"Rule"), &awsevents.RuleProps{
+		Description:  jsii.String("Routes GuardDuty findings for " + route.Name),
+		EventPattern: findingsEventPattern(route.SeverityBands, route.FindingTypes),
+	})
+
+	if topic != nil {
+		rule.AddTarget(awseventstargets.NewSnsTopic(topic, &awseventstargets.SnsTopicProps{
+			Message: findingsNotification(route.NotificationFormat),
+		}))
+	}
+
+	if route.Queue != nil {
+		rule.AddTarget(awseventstargets.NewSqsQueue(route.Queue, nil))
+	}
+
+	if route.Function != nil {
+		rule.AddTarget(awseventstargets.NewLambdaFunction(route.Function, &awseventstargets.LambdaFunctionProps{
+			RetryAttempts: jsii.Number(2),
+		}))
+	}
+
+	return rule, createdTopic
+}
+
+// findingsEventPattern matches GuardDuty findings in the given severity bands and
+// type prefixes (nil or empty = all)
+func findingsEventPattern(severityBands []SeverityBand, findingTypes []string) *awsevents.EventPattern {
+	pattern := &awsevents.EventPattern{
+		Source:     jsii.Strings("aws.guardduty"),
+		DetailType: jsii.Strings("GuardDuty Finding"),
+	}
+
+	detail := make(map[string]interface{})
+
+	if len(severityBands) > 0 {
+		severities := make([]interface{}, len(severityBands))
+		for i, band := range severityBands {
+			numericRange, ok := severityBandRanges[band]
+			if !ok {
+				panic(fmt.Sprintf("Unsupported SeverityBand: %s. Valid options: LOW, MEDIUM, HIGH, CRITICAL", band))
+			}
+			severities[i] = map[string]interface{}{"numeric": numericRange}
+		}
+		detail["severity"] = severities
+	}
+
+	if len(findingTypes) > 0 {
+		types := make([]interface{}, len(findingTypes))
+		for i, findingType := range findingTypes {
+			types[i] = map[string]interface{}{"prefix": findingType}
+		}
+		detail["type"] = types
+	}
+
+	if len(detail) > 0 {
+		pattern.Detail = &detail
+	}
+
+	return pattern
+}
+
+// findingsNotification builds the SNS message for the given format
+func findingsNotification(format NotificationFormat) awsevents.RuleTargetInput {
+	severity := awsevents.EventField_FromPath(jsii.String("$.detail.severity"))
+	findingType := awsevents.EventField_FromPath(jsii.String("$.detail.type"))
+	title := awsevents.EventField_FromPath(jsii.String("$.detail.title"))
+	description := awsevents.EventField_FromPath(jsii.String("$.detail.description"))
+	account := awsevents.EventField_FromPath(jsii.String("$.detail.accountId"))
+	region := awsevents.EventField_FromPath(jsii.String("$.detail.region"))
+	findingID := awsevents.EventField_FromPath(jsii.String("$.detail.id"))
+
+	switch format {
+	case "", NotificationFormatText:
+		return awsevents.RuleTargetInput_FromText(jsii.String(fmt.Sprintf(
+			"GuardDuty finding (severity %s) in account %s, region %s\n\n%s\n\nType: %s\n%s\n\nFinding ID: %s",
+			*severity, *account, *region, *title, *findingType, *description, *findingID)))
+
+	case NotificationFormatChatbot:
+		return awsevents.RuleTargetInput_FromObject(map[string]interface{}{
+			"version": "1.0",
+			"source":  "custom",
+			"content": map[string]interface{}{
+				"textType":    "client-markdown",
+				"title":       fmt.Sprintf(":rotating_light: GuardDuty %s (severity %s)", *findingType, *severity),
+				"description": fmt.Sprintf("*%s*\n%s\nAccount: %s | Region: %s | Finding: %s", *title, *description, *account, *region, *findingID),
+			},
+		})
+
+	case NotificationFormatJSON:
+		// No input: the target receives the whole event
+		return nil
+
+	default:
+		panic(fmt.Sprintf("Unsupported NotificationFormat: %s. Valid options: TEXT, CHATBOT, JSON", format))
+	}
+}
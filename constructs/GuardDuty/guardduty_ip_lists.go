@@ -0,0 +1,115 @@
+package guardduty
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsguardduty"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awss3assets"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// GuardDuty limits per detector and region
+const (
+	maxTrustedIPLists  = 1
+	maxThreatIntelSets = 6
+)
+
+// IPListFile is a local IP list uploaded as a CDK S3 asset and registered in GuardDuty.
+type IPListFile struct {
+	// Name of the list in GuardDuty.
+	// Required
+	Name string
+
+	// Path of the list file, relative to the project root (e.g. "security/trusted-ips.txt").
+	// Required
+	Path string
+
+	// Format of the file: "TXT" (one IP or CIDR per line), "STIX", "OTX_CSV",
+	// "ALIEN_VAULT", "PROOF_POINT" or "FIRE_EYE".
+	// Optional. Default: "TXT"
+	Format string
+}
+
+// validIPListFormats are the formats accepted by GuardDuty IP sets and threat intel sets
+var validIPListFormats = map[string]bool{
+	"TXT":         true,
+	"STIX":        true,
+	"OTX_CSV":     true,
+	"ALIEN_VAULT": true,
+	"PROOF_POINT": true,
+	"FIRE_EYE":    true,
+}
+
+// newTrustedIPLists registers trusted IP lists: GuardDuty generates no findings for
+// traffic from these addresses (e.g. our own vulnerability scanners)
+func newTrustedIPLists(scope constructs.Construct, id string, detector awsguardduty.CfnDetector, files []IPListFile) []awsguardduty.CfnIPSet {
+	if len(files) > maxTrustedIPLists {
+		panic(fmt.Sprintf("GuardDuty supports %d active trusted IP list per detector, got %d TrustedIPLists", maxTrustedIPLists, len(files)))
+	}
+
+	ipSets := make([]awsguardduty.CfnIPSet, 0, len(files))
+	for i, file := range files {
+		assetID := fmt.Sprintf("%sTrustedIPList%d", id, i)
+		format := ipListFormat("TrustedIPLists", file)
+		asset := newIPListAsset(scope, assetID+"Asset", file)
+
+		ipSets = append(ipSets, awsguardduty.NewCfnIPSet(scope, jsii.String(assetID), &awsguardduty.CfnIPSetProps{
+			DetectorId: detector.Ref(),
+			Name:       jsii.String(file.Name),
+			Format:     jsii.String(format),
+			Location:   asset.HttpUrl(),
+			Activate:   jsii.Bool(true),
+		}))
+	}
+
+	return ipSets
+}
+
+// newThreatIntelSets registers threat intel sets: GuardDuty generates findings for
+// traffic involving these known malicious addresses
+func newThreatIntelSets(scope constructs.Construct, id string, detector awsguardduty.CfnDetector, files []IPListFile) []awsguardduty.CfnThreatIntelSet {
+	if len(files) > maxThreatIntelSets {
+		panic(fmt.Sprintf("GuardDuty supports at most %d active threat intel sets per detector, got %d ThreatIntelSets", maxThreatIntelSets, len(files)))
+	}
+
+	threatIntelSets := make([]awsguardduty.CfnThreatIntelSet, 0, len(files))
+	for i, file := range files {
+		assetID := fmt.Sprintf("%sThreatIntelSet%d", id, i)
+		format := ipListFormat("ThreatIntelSets", file)
+		asset := newIPListAsset(scope, assetID+"Asset", file)
+
+		threatIntelSets = append(threatIntelSets, awsguardduty.NewCfnThreatIntelSet(scope, jsii.String(assetID), &awsguardduty.CfnThreatIntelSetProps{
+			DetectorId: detector.Ref(),
+			Name:       jsii.String(file.Name),
+			Format:     jsii.String(format),
+			Location:   asset.HttpUrl(),
+			Activate:   jsii.Bool(true),
+		}))
+	}
+
+	return threatIntelSets
+}
+
+// newIPListAsset uploads the list file to the CDK assets bucket. GuardDuty reads it
+// with the permissions of the deploying role (the CDK deploy role by default)
+func newIPListAsset(scope constructs.Construct, id string, file IPListFile) awss3assets.Asset {
+	return awss3assets.NewAsset(scope, jsii.String(id), &awss3assets.AssetProps{
+		Path: jsii.String(file.Path),
+	})
+}
+
+// ipListFormat validates the list and returns its format (default TXT)
+func ipListFormat(option string, file IPListFile) string {
+	if file.Name == "" || file.Path == "" {
+		panic(fmt.Sprintf("%s entries require Name and Path", option))
+	}
+
+	if file.Format == "" {
+		return "TXT"
+	}
+	if !validIPListFormats[file.Format] {
+		panic(fmt.Sprintf("Unsupported %s format for %s: %s. Valid options: TXT, STIX, OTX_CSV, ALIEN_VAULT, PROOF_POINT, FIRE_EYE", option, file.Name, file.Format))
+	}
+	return file.Format
+}
@@ -0,0 +1,247 @@
+package guardduty
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsevents"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsguardduty"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsiam"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awss3"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// MalwareScanStatus is the scan result GuardDuty publishes to EventBridge and, with
+// object tagging enabled, writes to the GuardDutyMalwareScanStatus object tag.
+type MalwareScanStatus string
+
+const (
+	// MalwareScanNoThreatsFound means the object was scanned and is clean
+	MalwareScanNoThreatsFound MalwareScanStatus = "NO_THREATS_FOUND"
+
+	// MalwareScanThreatsFound means malware was detected in the object
+	MalwareScanThreatsFound MalwareScanStatus = "THREATS_FOUND"
+
+	// MalwareScanUnsupported means the object could not be scanned (size, format or encryption)
+	MalwareScanUnsupported MalwareScanStatus = "UNSUPPORTED"
+
+	// MalwareScanAccessDenied means the plan role could not read the object
+	MalwareScanAccessDenied MalwareScanStatus = "ACCESS_DENIED"
+
+	// MalwareScanFailed means the scan failed because of an internal error
+	MalwareScanFailed MalwareScanStatus = "FAILED"
+)
+
+// MalwareScanStatusTagKey is the object tag GuardDuty writes when tagging is enabled.
+// Use it in bucket policies to deny reads of objects that are not NO_THREATS_FOUND
+const MalwareScanStatusTagKey = "GuardDutyMalwareScanStatus"
+
+// malwareProtectionPrincipal is the service principal that assumes the plan role
+const malwareProtectionPrincipal = "malware-protection-plan.guardduty.amazonaws.com"
+
+// S3MalwareProtectionOptions defines a GuardDuty Malware Protection for S3 plan.
+// Every new object uploaded under ObjectPrefixes is scanned (~$0.09 per GB scanned
+// + $0.215 per 1,000 objects evaluated). It does not require a GuardDuty detector
+type S3MalwareProtectionOptions struct {
+	// Bucket to protect.
+	// Required
+	Bucket awss3.IBucket
+
+	// ObjectPrefixes limits scanning to these key prefixes (e.g. "uploads/").
+	// Optional (maximum 5). Default: the whole bucket
+	ObjectPrefixes []string
+
+	// EnableObjectTagging writes the scan result to the GuardDutyMalwareScanStatus object tag.
+	// Optional. Default: true
+	EnableObjectTagging *bool
+
+	// Tags to apply to the malware protection plan.
+	// Optional
+	Tags *[]*awsguardduty.CfnMalwareProtectionPlan_TagItemProperty
+}
+
+// NewS3MalwareProtectionPlan creates a CfnMalwareProtectionPlan for a bucket together
+// with the IAM role GuardDuty assumes to scan objects, tag them and manage the
+// EventBridge rule that reports new uploads.
+//
+// Example:
+//
+//	guardduty.NewS3MalwareProtectionPlan(stack, "LandingZoneMalwareProtection",
+//	    guardduty.S3MalwareProtectionOptions{
+//	        Bucket:         bucket,
+//	        ObjectPrefixes: []string{"uploads/"},
+//	    })
+func NewS3MalwareProtectionPlan(scope constructs.Construct, id string, options S3MalwareProtectionOptions) awsguardduty.CfnMalwareProtectionPlan {
+	if options.Bucket == nil {
+		panic("S3MalwareProtectionOptions.Bucket is required")
+	}
+	if len(options.ObjectPrefixes) > 5 {
+		panic(fmt.Sprintf("S3MalwareProtectionOptions.ObjectPrefixes accepts at most 5 prefixes, got %d", len(options.ObjectPrefixes)))
+	}
+
+	enableTagging := true
+	if options.EnableObjectTagging != nil {
+		enableTagging = *options.EnableObjectTagging
+	}
+
+	role := newMalwareProtectionRole(scope, id+"Role", options.Bucket)
+
+	s3Bucket := &awsguardduty.CfnMalwareProtectionPlan_S3BucketProperty{
+		BucketName: options.Bucket.BucketName(),
+	}
+	if len(options.ObjectPrefixes) > 0 {
+		s3Bucket.ObjectPrefixes = jsii.Strings(options.ObjectPrefixes...)
+	}
+
+	taggingStatus := "DISABLED"
+	if enableTagging {
+		taggingStatus = "ENABLED"
+	}
+
+	plan := awsguardduty.NewCfnMalwareProtectionPlan(scope, jsii.String(id), &awsguardduty.CfnMalwareProtectionPlanProps{
+		Role: role.RoleArn(),
+		ProtectedResource: &awsguardduty.CfnMalwareProtectionPlan_CFNProtectedResourceProperty{
+			S3Bucket: s3Bucket,
+		},
+		Actions: &awsguardduty.CfnMalwareProtectionPlan_CFNActionsProperty{
+			Tagging: &awsguardduty.CfnMalwareProtectionPlan_CFNTaggingProperty{
+				Status: jsii.String(taggingStatus),
+			},
+		},
+		Tags: options.Tags,
+	})
+
+	// GuardDuty validates the role permissions when the plan is created
+	plan.Node().AddDependency(role)
+
+	return plan
+}
+
+// newMalwareProtectionRole creates the role described in the GuardDuty Malware
+// Protection for S3 documentation, scoped to a single bucket
+func newMalwareProtectionRole(scope constructs.Construct, id string, bucket awss3.IBucket) awsiam.Role {
+	stack := awscdk.Stack_Of(scope)
+
+	role := awsiam.NewRole(scope, jsii.String(id), &awsiam.RoleProps{
+		AssumedBy:   awsiam.NewServicePrincipal(jsii.String(malwareProtectionPrincipal), nil),
+		Description: jsii.String("GuardDuty Malware Protection for S3 on " + *bucket.BucketName()),
+	})
+
+	// EventBridge managed rule that notifies GuardDuty of new objects
+	managedRuleArn := stack.FormatArn(&awscdk.ArnComponents{
+		Service:      jsii.String("events"),
+		Resource:     jsii.String("rule"),
+		ResourceName: jsii.String("DO-NOT-DELETE-AmazonGuardDutyMalwareProtectionS3*"),
+		ArnFormat:    awscdk.ArnFormat_SLASH_RESOURCE_NAME,
+	})
+
+	role.AddToPolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Sid:       jsii.String("AllowManagedRuleToSendS3EventsToGuardDuty"),
+		Actions:   jsii.Strings("events:PutRule", "events:DeleteRule", "events:PutTargets", "events:RemoveTargets"),
+		Resources: jsii.Strings(*managedRuleArn),
+		Conditions: &map[string]interface{}{
+			"StringLike": map[string]interface{}{
+				"events:ManagedBy": malwareProtectionPrincipal,
+			},
+		},
+	}))
+
+	role.AddToPolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Sid:       jsii.String("AllowGuardDutyToMonitorEventBridgeManagedRule"),
+		Actions:   jsii.Strings("events:DescribeRule", "events:ListTargetsByRule"),
+		Resources: jsii.Strings(*managedRuleArn),
+	}))
+
+	role.AddToPolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Sid:       jsii.String("AllowEnableS3EventBridgeEvents"),
+		Actions:   jsii.Strings("s3:PutBucketNotification", "s3:GetBucketNotification"),
+		Resources: jsii.Strings(*bucket.BucketArn()),
+	}))
+
+	role.AddToPolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Sid:       jsii.String("AllowPostScanTag"),
+		Actions:   jsii.Strings("s3:PutObjectTagging", "s3:GetObjectTagging", "s3:PutObjectVersionTagging", "s3:GetObjectVersionTagging"),
+		Resources: jsii.Strings(*bucket.ArnForObjects(jsii.String("*"))),
+	}))
+
+	role.AddToPolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Sid:       jsii.String("AllowPutValidationObject"),
+		Actions:   jsii.Strings("s3:PutObject"),
+		Resources: jsii.Strings(*bucket.ArnForObjects(jsii.String("malware-protection-resource-validation-object"))),
+	}))
+
+	role.AddToPolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Sid:       jsii.String("AllowCheckBucketOwnership"),
+		Actions:   jsii.Strings("s3:ListBucket"),
+		Resources: jsii.Strings(*bucket.BucketArn()),
+	}))
+
+	role.AddToPolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Sid:       jsii.String("AllowMalwareScan"),
+		Actions:   jsii.Strings("s3:GetObject", "s3:GetObjectVersion"),
+		Resources: jsii.Strings(*bucket.ArnForObjects(jsii.String("*"))),
+	}))
+
+	// Customer managed KMS keys must let the role decrypt objects through S3
+	if key := bucket.EncryptionKey(); key != nil {
+		role.AddToPolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+			Sid:       jsii.String("AllowDecryptForMalwareScan"),
+			Actions:   jsii.Strings("kms:GenerateDataKey", "kms:Decrypt"),
+			Resources: jsii.Strings(*key.KeyArn()),
+			Conditions: &map[string]interface{}{
+				"StringLike": map[string]interface{}{
+					"kms:ViaService": fmt.Sprintf("s3.%s.amazonaws.com", *stack.Region()),
+				},
+			},
+		}))
+	}
+
+	return role
+}
+
+// MalwareScanResultEventPattern matches the "GuardDuty Malware Protection Object Scan Result"
+// events of a bucket, optionally filtered by object key prefix/suffix and scan result
+// (nil or empty = any). Shared with the EventBridge integrations so targets can be
+// triggered only for clean objects.
+//
+// The event detail carries s3ObjectDetails (bucketName, objectKey, eTag, versionId)
+// and scanResultDetails (scanResultStatus, threats)
+func MalwareScanResultEventPattern(bucketName string, objectKeyPrefix, objectKeySuffix *string, statuses ...MalwareScanStatus) *awsevents.EventPattern {
+	s3ObjectDetails := map[string]interface{}{
+		"bucketName": []interface{}{bucketName},
+	}
+
+	objectFilter := make(map[string]interface{})
+	if objectKeyPrefix != nil {
+		objectFilter["prefix"] = *objectKeyPrefix
+	}
+	if objectKeySuffix != nil {
+		objectFilter["suffix"] = *objectKeySuffix
+	}
+	if len(objectFilter) > 0 {
+		s3ObjectDetails["objectKey"] = []interface{}{objectFilter}
+	}
+
+	detail := map[string]interface{}{
+		"scanStatus":      []interface{}{"COMPLETED"},
+		"resourceType":    []interface{}{"S3_OBJECT"},
+		"s3ObjectDetails": s3ObjectDetails,
+	}
+
+	if len(statuses) > 0 {
+		scanResults := make([]interface{}, len(statuses))
+		for i, status := range statuses {
+			scanResults[i] = string(status)
+		}
+		detail["scanResultDetails"] = map[string]interface{}{
+			"scanResultStatus": scanResults,
+		}
+	}
+
+	return &awsevents.EventPattern{
+		Source:     jsii.Strings("aws.guardduty"),
+		DetailType: jsii.Strings("GuardDuty Malware Protection Object Scan Result"),
+		Detail:     &detail,
+	}
+}
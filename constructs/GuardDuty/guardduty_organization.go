@@ -0,0 +1,206 @@
+package guardduty
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsguardduty"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsiam"
+	"github.com/aws/aws-cdk-go/awscdk/v2/customresources"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// OrganizationAutoEnable controls which member accounts get GuardDuty (and its features) automatically.
+type OrganizationAutoEnable string
+
+const (
+	// OrganizationAutoEnableNew enables GuardDuty in accounts that join the organization
+	OrganizationAutoEnableNew OrganizationAutoEnable = "NEW"
+
+	// OrganizationAutoEnableAll enables GuardDuty in new and existing member accounts
+	OrganizationAutoEnableAll OrganizationAutoEnable = "ALL"
+
+	// OrganizationAutoEnableNone leaves member accounts unchanged (each account manages GuardDuty)
+	OrganizationAutoEnableNone OrganizationAutoEnable = "NONE"
+)
+
+// GuardDutyOrganizationOptions configures GuardDuty for an AWS Organization.
+//
+// GuardDuty is regional: deploy the stacks in every region to protect. Typical setup:
+//   - Management account stack: DelegatedAdminAccountID = security account
+//   - Security account stack: AutoEnableMembers = NEW or ALL, with the same feature flags
+//     as the security account detector (DetectorType and Enable* flags)
+type GuardDutyOrganizationOptions struct {
+	// DelegatedAdminAccountID designates the GuardDuty delegated administrator of the organization.
+	// Optional. Only valid in a stack deployed to the organization management account.
+	// GuardDuty allows a single administrator: to change it, deploy once without
+	// DelegatedAdminAccountID (disables the current one), then with the new account.
+	// Changing it in a single deployment fails and rolls back
+	DelegatedAdminAccountID string
+
+	// AutoEnableMembers configures organization-wide auto-enable from this stack's detector,
+	// applying the features resolved from GuardDutyFactoryProps to member accounts.
+	// Optional. Only valid in a stack deployed to the delegated administrator account.
+	// Removing it (or Organization) resets auto-enable and every feature to NONE
+	AutoEnableMembers OrganizationAutoEnable
+}
+
+// newOrganizationConfiguration designates the delegated administrator and/or updates the
+// organization configuration through AwsCustomResource (no CloudFormation resource exists)
+func newOrganizationConfiguration(scope constructs.Construct, id string, detector awsguardduty.CfnDetector, props GuardDutyFactoryProps) {
+	options := props.Organization
+
+	if options.DelegatedAdminAccountID == "" && options.AutoEnableMembers == "" {
+		panic("GuardDutyFactoryProps.Organization requires DelegatedAdminAccountID and/or AutoEnableMembers")
+	}
+
+	var adminAccount customresources.AwsCustomResource
+
+	// Management account: designate the delegated administrator
+	if options.DelegatedAdminAccountID != "" {
+		adminCall := func(action string) *customresources.AwsSdkCall {
+			return &customresources.AwsSdkCall{
+				Service: jsii.String("GuardDuty"),
+				Action:  jsii.String(action),
+				Parameters: map[string]interface{}{
+					"AdminAccountId": options.DelegatedAdminAccountID,
+				},
+				PhysicalResourceId: customresources.PhysicalResourceId_Of(jsii.String("GuardDutyAdmin-" + options.DelegatedAdminAccountID)),
+			}
+		}
+
+		adminAccount = customresources.NewAwsCustomResource(scope, jsii.String(id+"OrganizationAdmin"), &customresources.AwsCustomResourceProps{
+			OnCreate: adminCall("enableOrganizationAdminAccount"),
+			// A new account is enabled while the previous one is still administrator:
+			// GuardDuty rejects it, so the change fails loudly instead of being ignored
+			OnUpdate: adminCall("enableOrganizationAdminAccount"),
+			OnDelete: adminCall("disableOrganizationAdminAccount"),
+			Policy: customresources.AwsCustomResourcePolicy_FromStatements(&[]awsiam.PolicyStatement{
+				awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+					Actions: jsii.Strings(
+						"guardduty:EnableOrganizationAdminAccount",
+						"guardduty:DisableOrganizationAdminAccount",
+						"guardduty:ListOrganizationAdminAccounts",
+						"organizations:EnableAWSServiceAccess",
+						"organizations:RegisterDelegatedAdministrator",
+						"organizations:DeregisterDelegatedAdministrator",
+						"organizations:ListDelegatedAdministrators",
+						"organizations:ListAWSServiceAccessForOrganization",
+						"organizations:DescribeOrganization",
+						"organizations:DescribeAccount",
+					),
+					Resources: jsii.Strings("*"),
+				}),
+				awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+					Actions:   jsii.Strings("iam:CreateServiceLinkedRole"),
+					Resources: jsii.Strings("*"),
+					Conditions: &map[string]interface{}{
+						"StringEquals": map[string]interface{}{
+							"iam:AWSServiceName": "guardduty.amazonaws.com",
+						},
+					},
+				}),
+			}),
+			InstallLatestAwsSdk: jsii.Bool(false),
+		})
+		adminAccount.Node().AddDependency(detector)
+	}
+
+	// Delegated administrator account: auto-enable members and features
+	if options.AutoEnableMembers != "" {
+		switch options.AutoEnableMembers {
+		case OrganizationAutoEnableNew, OrganizationAutoEnableAll, OrganizationAutoEnableNone:
+		default:
+			panic(fmt.Sprintf("Unsupported Organization.AutoEnableMembers: %s. Valid options: NEW, ALL, NONE", options.AutoEnableMembers))
+		}
+
+		updateCall := &customresources.AwsSdkCall{
+			Service: jsii.String("GuardDuty"),
+			Action:  jsii.String("updateOrganizationConfiguration"),
+			Parameters: map[string]interface{}{
+				"DetectorId":                    detector.Ref(),
+				"AutoEnableOrganizationMembers": string(options.AutoEnableMembers),
+				"Features":                      organizationFeatureConfigurations(resolveFeatures(props), options.AutoEnableMembers),
+			},
+			PhysicalResourceId: customresources.PhysicalResourceId_Of(jsii.String(id + "OrganizationConfiguration")),
+		}
+
+		// Removing the configuration stops auto-enabling GuardDuty and its features in
+		// member accounts (accounts already enabled keep their detectors)
+		resetCall := &customresources.AwsSdkCall{
+			Service: jsii.String("GuardDuty"),
+			Action:  jsii.String("updateOrganizationConfiguration"),
+			Parameters: map[string]interface{}{
+				"DetectorId":                    detector.Ref(),
+				"AutoEnableOrganizationMembers": string(OrganizationAutoEnableNone),
+				"Features":                      organizationFeatureConfigurations(nil, OrganizationAutoEnableNone),
+			},
+			PhysicalResourceId: customresources.PhysicalResourceId_Of(jsii.String(id + "OrganizationConfiguration")),
+		}
+
+		configuration := customresources.NewAwsCustomResource(scope, jsii.String(id+"OrganizationConfiguration"), &customresources.AwsCustomResourceProps{
+			OnCreate: updateCall,
+			OnUpdate: updateCall,
+			OnDelete: resetCall,
+			Policy: customresources.AwsCustomResourcePolicy_FromStatements(&[]awsiam.PolicyStatement{
+				awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+					Actions:   jsii.Strings("guardduty:UpdateOrganizationConfiguration", "guardduty:DescribeOrganizationConfiguration"),
+					Resources: jsii.Strings("*"),
+				}),
+			}),
+			InstallLatestAwsSdk: jsii.Bool(false),
+		})
+		configuration.Node().AddDependency(detector)
+
+		// Management account that is also its own administrator
+		if adminAccount != nil {
+			configuration.Node().AddDependency(adminAccount)
+		}
+	}
+}
+
+// organizationFeatureConfigurations mirrors the resolved detector features in the
+// organization configuration: enabled features use autoEnable, the rest NONE
+func organizationFeatureConfigurations(features []detectorFeature, autoEnable OrganizationAutoEnable) []interface{} {
+	enabled := make(map[string]detectorFeature)
+	for _, feature := range features {
+		enabled[feature.name] = feature
+	}
+
+	names := managedFeatures
+	if _, ok := enabled["EKS_RUNTIME_MONITORING"]; ok {
+		names = append(append([]string{}, managedFeatures...), "EKS_RUNTIME_MONITORING")
+	}
+
+	configurations := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		feature, ok := enabled[name]
+		if !ok {
+			configurations = append(configurations, map[string]interface{}{
+				"Name":       name,
+				"AutoEnable": string(OrganizationAutoEnableNone),
+			})
+			continue
+		}
+
+		configuration := map[string]interface{}{
+			"Name":       name,
+			"AutoEnable": string(autoEnable),
+		}
+
+		if len(feature.additionalConfiguration) > 0 {
+			additionalConfiguration := make([]interface{}, len(feature.additionalConfiguration))
+			for i, additionalName := range feature.additionalConfiguration {
+				additionalConfiguration[i] = map[string]interface{}{
+					"Name":       additionalName,
+					"AutoEnable": string(autoEnable),
+				}
+			}
+			configuration["AdditionalConfiguration"] = additionalConfiguration
+		}
+
+		configurations = append(configurations, configuration)
+	}
+
+	return configurations
+}
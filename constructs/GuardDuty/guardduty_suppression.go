@@ -0,0 +1,151 @@
+package guardduty
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsguardduty"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// FilterAction defines what GuardDuty does with findings matching a suppression rule.
+type FilterAction string
+
+const (
+	// FilterActionArchive auto-archives matching findings (suppression: they are not
+	// exported to EventBridge, S3 or Security Hub, but stay visible as archived)
+	FilterActionArchive FilterAction = "ARCHIVE"
+
+	// FilterActionNoop saves the filter without archiving (useful to review matches first)
+	FilterActionNoop FilterAction = "NOOP"
+)
+
+// SuppressionRule declares a CfnFilter. Criteria are combined with AND; at least one is required.
+type SuppressionRule struct {
+	// Name of the filter (3-64 characters: letters, digits, '.', '-' and '_').
+	// Required
+	Name string
+
+	// Description shown in the console (e.g. why the findings are benign).
+	// Optional
+	Description string
+
+	// Action for matching findings.
+	// Optional. Default: FilterActionArchive
+	Action FilterAction
+
+	// FindingTypes matches these exact finding types (e.g. "Recon:EC2/PortProbeUnprotectedPort").
+	// Optional
+	FindingTypes []string
+
+	// ResourceTag matches findings on EC2 instances with this tag (e.g. Role=vulnerability-scanner).
+	// Optional
+	ResourceTag *ResourceTagCriterion
+
+	// MaxSeverityBand matches findings up to this band (e.g. SeverityLow = severity below 4.0).
+	// Optional
+	MaxSeverityBand SeverityBand
+}
+
+// ResourceTagCriterion matches a tag of the affected EC2 instance.
+type ResourceTagCriterion struct {
+	Key   string
+	Value string
+}
+
+// severityBandUpperBounds is the exclusive upper severity of each band
+var severityBandUpperBounds = map[SeverityBand]int{
+	SeverityLow:      4,
+	SeverityMedium:   7,
+	SeverityHigh:     9,
+	SeverityCritical: 11,
+}
+
+var filterNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.\-]{3,64}$`)
+
+// newSuppressionFilters creates a CfnFilter per rule. Ranks follow the declared order
+// (the first rule is evaluated first), like WAF rule priorities
+func newSuppressionFilters(scope constructs.Construct, id string, detector awsguardduty.CfnDetector, rules []SuppressionRule) []awsguardduty.CfnFilter {
+	if len(rules) > 100 {
+		panic(fmt.Sprintf("GuardDuty supports at most 100 filters per detector, got %d SuppressionRules", len(rules)))
+	}
+
+	filters := make([]awsguardduty.CfnFilter, 0, len(rules))
+	names := make(map[string]bool)
+
+	for i, rule := range rules {
+		if !filterNamePattern.MatchString(rule.Name) {
+			panic(fmt.Sprintf("SuppressionRule name %q must have 3-64 characters: letters, digits, '.', '-' and '_'", rule.Name))
+		}
+		if names[rule.Name] {
+			panic(fmt.Sprintf("SuppressionRule name %s is used more than once", rule.Name))
+		}
+		names[rule.Name] = true
+
+		action := rule.Action
+		switch action {
+		case "":
+			action = FilterActionArchive
+		case FilterActionArchive, FilterActionNoop:
+		default:
+			panic(fmt.Sprintf("Unsupported SuppressionRule %s Action: %s. Valid options: ARCHIVE, NOOP", rule.Name, action))
+		}
+
+		filterProps := &awsguardduty.CfnFilterProps{
+			DetectorId: detector.Ref(),
+			Name:       jsii.String(rule.Name),
+			Action:     jsii.String(string(action)),
+			Rank:       jsii.Number(i + 1),
+			FindingCriteria: &awsguardduty.CfnFilter_FindingCriteriaProperty{
+				Criterion: suppressionCriteria(rule),
+			},
+		}
+		if rule.Description != "" {
+			filterProps.Description = jsii.String(rule.Description)
+		}
+
+		filters = append(filters, awsguardduty.NewCfnFilter(scope, jsii.String(fmt.Sprintf("%sSuppression%d", id, i)), filterProps))
+	}
+
+	return filters
+}
+
+// suppressionCriteria builds the FindingCriteria.Criterion map of a rule
+func suppressionCriteria(rule SuppressionRule) map[string]interface{} {
+	criteria := make(map[string]interface{})
+
+	if len(rule.FindingTypes) > 0 {
+		criteria["type"] = map[string]interface{}{
+			"Eq": rule.FindingTypes,
+		}
+	}
+
+	if rule.ResourceTag != nil {
+		if rule.ResourceTag.Key == "" || rule.ResourceTag.Value == "" {
+			panic(fmt.Sprintf("SuppressionRule %s ResourceTag requires Key and Value", rule.Name))
+		}
+		criteria["resource.instanceDetails.tags.key"] = map[string]interface{}{
+			"Eq": []string{rule.ResourceTag.Key},
+		}
+		criteria["resource.instanceDetails.tags.value"] = map[string]interface{}{
+			"Eq": []string{rule.ResourceTag.Value},
+		}
+	}
+
+	if rule.MaxSeverityBand != "" {
+		upperBound, ok := severityBandUpperBounds[rule.MaxSeverityBand]
+		if !ok {
+			panic(fmt.Sprintf("Unsupported SuppressionRule %s MaxSeverityBand: %s. Valid options: LOW, MEDIUM, HIGH, CRITICAL", rule.Name, rule.MaxSeverityBand))
+		}
+		criteria["severity"] = map[string]interface{}{
+			"LessThan": upperBound,
+		}
+	}
+
+	if len(criteria) == 0 {
+		panic(fmt.Sprintf("SuppressionRule %s requires at least one criterion (FindingTypes, ResourceTag or MaxSeverityBand)", rule.Name))
+	}
+
+	return criteria
+}
@@ -0,0 +1,68 @@
+package guardduty
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validFindingPublishingFrequencies are the values accepted by CfnDetector
+var validFindingPublishingFrequencies = map[string]bool{
+	"FIFTEEN_MINUTES": true,
+	"ONE_HOUR":        true,
+	"SIX_HOURS":       true,
+}
+
+// validateProps checks option combinations the strategies would otherwise drop or
+// ignore silently. All problems are reported in a single panic so synth fails once
+// with every fix needed
+func validateProps(props GuardDutyFactoryProps) {
+	errors := make([]string, 0)
+
+	if props.FindingPublishingFrequency != nil && !validFindingPublishingFrequencies[*props.FindingPublishingFrequency] {
+		errors = append(errors, fmt.Sprintf("FindingPublishingFrequency %q is not supported. Valid options: FIFTEEN_MINUTES, ONE_HOUR, SIX_HOURS", *props.FindingPublishingFrequency))
+	}
+
+	if props.DetectorType != GuardDutyTypeCustom {
+		// Feature flags are only read by the Custom strategy
+		flags := []struct {
+			flag *bool
+			name string
+		}{
+			{props.EnableS3Protection, "EnableS3Protection"},
+			{props.EnableEKSProtection, "EnableEKSProtection"},
+			{props.EnableEKSRuntimeMonitoring, "EnableEKSRuntimeMonitoring"},
+			{props.EnableMalwareProtection, "EnableMalwareProtection"},
+			{props.EnableRDSProtection, "EnableRDSProtection"},
+			{props.EnableLambdaProtection, "EnableLambdaProtection"},
+			{props.EnableRuntimeMonitoring, "EnableRuntimeMonitoring"},
+			{props.EnableEC2AgentManagement, "EnableEC2AgentManagement"},
+			{props.EnableFargateAgentManagement, "EnableFargateAgentManagement"},
+		}
+
+		for _, f := range flags {
+			if f.flag != nil {
+				errors = append(errors, fmt.Sprintf("%s is only used by DetectorType CUSTOM (got %s): remove it or set DetectorType to GuardDutyTypeCustom", f.name, props.DetectorType))
+			}
+		}
+	} else {
+		if isEnabled(props.EnableEKSRuntimeMonitoring) && !isEnabled(props.EnableEKSProtection) {
+			errors = append(errors, "EnableEKSRuntimeMonitoring requires EnableEKSProtection=true")
+		}
+
+		if isEnabled(props.EnableEKSRuntimeMonitoring) && isEnabled(props.EnableRuntimeMonitoring) {
+			errors = append(errors, "EnableEKSRuntimeMonitoring and EnableRuntimeMonitoring cannot both be enabled: RUNTIME_MONITORING already covers EKS, remove EnableEKSRuntimeMonitoring")
+		}
+
+		if isEnabled(props.EnableEC2AgentManagement) && !isEnabled(props.EnableRuntimeMonitoring) {
+			errors = append(errors, "EnableEC2AgentManagement requires EnableRuntimeMonitoring=true")
+		}
+
+		if isEnabled(props.EnableFargateAgentManagement) && !isEnabled(props.EnableRuntimeMonitoring) {
+			errors = append(errors, "EnableFargateAgentManagement requires EnableRuntimeMonitoring=true")
+		}
+	}
+
+	if len(errors) > 0 {
+		panic(fmt.Sprintf("Invalid GuardDutyFactoryProps:\n  - %s", strings.Join(errors, "\n  - ")))
+	}
+}
@@ -0,0 +1,27 @@
+#!/bin/bash
+
+set -e
+
+echo "🔨 Building Lambda: guardduty-blocklist"
+echo "======================================"
+
+# Change to Lambda directory
+cd "$(dirname "$0")/lambda/guardduty-blocklist"
+
+echo "📦 Downloading Go dependencies (verified against go.sum)..."
+go mod download
+
+echo "🏗️  Building for ARM64 (Graviton2)..."
+# provided.al2 runtimes execute a binary named "bootstrap"
+GOOS=linux GOARCH=arm64 CGO_ENABLED=0 go build -tags lambda.norpc -ldflags="-s -w" -o bootstrap main.go
+
+echo "📊 Build info:"
+ls -lh bootstrap
+file bootstrap
+
+echo ""
+echo "✅ Lambda built successfully!"
+echo ""
+echo "Next steps:"
+echo "  1. Enable WAFFactoryProps.GuardDutyBlocklist in your stack"
+echo "  2. Deploy: cdk deploy"
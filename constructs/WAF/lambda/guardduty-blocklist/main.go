@@ -0,0 +1,421 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	waftypes "github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+)
+
+// Config holds Lambda configuration from environment variables
+type Config struct {
+	TableName            string
+	WAFScope             string
+	IPv4SetName          string
+	IPv4SetID            string
+	IPv6SetName          string
+	IPv6SetID            string
+	BlockDurationSeconds int
+	MaxBlockedIPs        int
+}
+
+// Finding is the subset of a GuardDuty finding used to extract remote IPs
+type Finding struct {
+	ID       string  `json:"id"`
+	Type     string  `json:"type"`
+	Severity float64 `json:"severity"`
+	Service  struct {
+		Action         FindingAction `json:"action"`
+		AdditionalInfo struct {
+			Sample bool `json:"sample"`
+		} `json:"additionalInfo"`
+	} `json:"service"`
+}
+
+// FindingAction contains the action types that carry a remote IP address
+type FindingAction struct {
+	NetworkConnectionAction *struct {
+		ConnectionDirection string           `json:"connectionDirection"`
+		RemoteIPDetails     *RemoteIPDetails `json:"remoteIpDetails"`
+	} `json:"networkConnectionAction"`
+	AwsAPICallAction *struct {
+		RemoteIPDetails *RemoteIPDetails `json:"remoteIpDetails"`
+	} `json:"awsApiCallAction"`
+	KubernetesAPICallAction *struct {
+		RemoteIPDetails *RemoteIPDetails `json:"remoteIpDetails"`
+	} `json:"kubernetesApiCallAction"`
+	RdsLoginAttemptAction *struct {
+		RemoteIPDetails *RemoteIPDetails `json:"remoteIpDetails"`
+	} `json:"rdsLoginAttemptAction"`
+	PortProbeAction *struct {
+		PortProbeDetails []struct {
+			RemoteIPDetails *RemoteIPDetails `json:"remoteIpDetails"`
+		} `json:"portProbeDetails"`
+	} `json:"portProbeAction"`
+}
+
+// RemoteIPDetails is the remote host reported by GuardDuty
+type RemoteIPDetails struct {
+	IPAddressV4 string `json:"ipAddressV4"`
+	IPAddressV6 string `json:"ipAddressV6"`
+}
+
+// blockedIP is an entry of the blocklist table
+type blockedIP struct {
+	IP        string
+	ExpiresAt int64
+}
+
+// maxUpdateAttempts bounds the retries on WAFOptimisticLockException
+const maxUpdateAttempts = 5
+
+var (
+	cfg       Config
+	wafClient *wafv2.Client
+	ddbClient *dynamodb.Client
+)
+
+// init initializes AWS clients and loads configuration (runs once per Lambda container)
+func init() {
+	cfg = Config{
+		TableName:            getEnv("TABLE_NAME", ""),
+		WAFScope:             getEnv("WAF_SCOPE", "REGIONAL"),
+		IPv4SetName:          getEnv("IPV4_SET_NAME", ""),
+		IPv4SetID:            getEnv("IPV4_SET_ID", ""),
+		IPv6SetName:          getEnv("IPV6_SET_NAME", ""),
+		IPv6SetID:            getEnv("IPV6_SET_ID", ""),
+		BlockDurationSeconds: getEnvInt("BLOCK_DURATION_SECONDS", 86400), // 24 hours
+		MaxBlockedIPs:        getEnvInt("MAX_BLOCKED_IPS", 10000),        // WAF IP set limit
+	}
+
+	// Validate required configuration
+	if cfg.TableName == "" || cfg.IPv4SetName == "" || cfg.IPv4SetID == "" || cfg.IPv6SetName == "" || cfg.IPv6SetID == "" {
+		log.Fatal("TABLE_NAME, IPV4_SET_NAME, IPV4_SET_ID, IPV6_SET_NAME and IPV6_SET_ID environment variables are required")
+	}
+
+	// Initialize AWS SDK clients
+	awsConfig, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+
+	wafClient = wafv2.NewFromConfig(awsConfig)
+	ddbClient = dynamodb.NewFromConfig(awsConfig)
+
+	log.Println("Lambda initialized successfully")
+}
+
+// handler processes GuardDuty findings (adds their remote IPs to the blocklist)
+// and scheduled events (removes expired IPs). Both end by syncing the WAF IP sets
+// with the non-expired entries of the table, so the table is the source of truth
+func handler(ctx context.Context, event events.CloudWatchEvent) error {
+	log.Printf("Processing event: %s (%s)", event.ID, event.DetailType)
+
+	if event.DetailType == "GuardDuty Finding" {
+		var finding Finding
+		if err := json.Unmarshal(event.Detail, &finding); err != nil {
+			return fmt.Errorf("failed to parse finding: %w", err)
+		}
+
+		if finding.Service.AdditionalInfo.Sample {
+			log.Printf("Skipping sample finding %s", finding.ID)
+			return nil
+		}
+
+		ips := remoteIPs(finding)
+		if len(ips) == 0 {
+			log.Printf("Finding %s (%s) has no public remote IP to block", finding.ID, finding.Type)
+			return nil
+		}
+
+		expiresAt := time.Now().Add(time.Duration(cfg.BlockDurationSeconds) * time.Second).Unix()
+		for _, ip := range ips {
+			if err := putBlockedIP(ctx, ip, expiresAt, finding); err != nil {
+				return fmt.Errorf("failed to store %s: %w", ip, err)
+			}
+			log.Printf("Blocking %s until %s (finding %s, %s, severity %.1f)",
+				ip, time.Unix(expiresAt, 0).UTC().Format(time.RFC3339), finding.ID, finding.Type, finding.Severity)
+		}
+	}
+
+	return syncIPSets(ctx)
+}
+
+// remoteIPs extracts the public remote IPs of a finding. Outbound connections are
+// ignored: their remote host is contacted by our workload, not a client of the Web ACL
+func remoteIPs(finding Finding) []string {
+	details := make([]*RemoteIPDetails, 0)
+	action := finding.Service.Action
+
+	if action.NetworkConnectionAction != nil && action.NetworkConnectionAction.ConnectionDirection != "OUTBOUND" {
+		details = append(details, action.NetworkConnectionAction.RemoteIPDetails)
+	}
+	if action.AwsAPICallAction != nil {
+		details = append(details, action.AwsAPICallAction.RemoteIPDetails)
+	}
+	if action.KubernetesAPICallAction != nil {
+		details = append(details, action.KubernetesAPICallAction.RemoteIPDetails)
+	}
+	if action.RdsLoginAttemptAction != nil {
+		details = append(details, action.RdsLoginAttemptAction.RemoteIPDetails)
+	}
+	if action.PortProbeAction != nil {
+		for _, probe := range action.PortProbeAction.PortProbeDetails {
+			details = append(details, probe.RemoteIPDetails)
+		}
+	}
+
+	seen := make(map[string]bool)
+	ips := make([]string, 0)
+	for _, detail := range details {
+		if detail == nil {
+			continue
+		}
+		for _, value := range []string{detail.IPAddressV4, detail.IPAddressV6} {
+			addr, err := netip.ParseAddr(value)
+			if err != nil {
+				continue
+			}
+			addr = addr.Unmap()
+			if !isPublic(addr) || seen[addr.String()] {
+				continue
+			}
+			seen[addr.String()] = true
+			ips = append(ips, addr.String())
+		}
+	}
+
+	return ips
+}
+
+// isPublic discards private, loopback, link-local and other non-routable addresses
+func isPublic(addr netip.Addr) bool {
+	return !addr.IsPrivate() && !addr.IsLoopback() && !addr.IsLinkLocalUnicast() &&
+		!addr.IsMulticast() && !addr.IsUnspecified()
+}
+
+// putBlockedIP stores (or extends) the block of an IP. The TTL attribute keeps
+// one extra day so the table is only cleaned up after the sweep removed the IP
+func putBlockedIP(ctx context.Context, ip string, expiresAt int64, finding Finding) error {
+	_, err := ddbClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(cfg.TableName),
+		Item: map[string]dynamodbtypes.AttributeValue{
+			"ip":          &dynamodbtypes.AttributeValueMemberS{Value: ip},
+			"expiresAt":   &dynamodbtypes.AttributeValueMemberN{Value: strconv.FormatInt(expiresAt, 10)},
+			"ttl":         &dynamodbtypes.AttributeValueMemberN{Value: strconv.FormatInt(expiresAt+86400, 10)},
+			"findingId":   &dynamodbtypes.AttributeValueMemberS{Value: finding.ID},
+			"findingType": &dynamodbtypes.AttributeValueMemberS{Value: finding.Type},
+			"severity":    &dynamodbtypes.AttributeValueMemberN{Value: strconv.FormatFloat(finding.Severity, 'f', -1, 64)},
+		},
+	})
+	return err
+}
+
+// syncIPSets writes the non-expired IPs to the WAF IP sets and deletes expired entries
+func syncIPSets(ctx context.Context) error {
+	now := time.Now().Unix()
+
+	active := make([]blockedIP, 0)
+	expired := make([]blockedIP, 0)
+
+	paginator := dynamodb.NewScanPaginator(ddbClient, &dynamodb.ScanInput{
+		TableName: aws.String(cfg.TableName),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to scan blocklist table: %w", err)
+		}
+
+		for _, item := range page.Items {
+			entry, err := parseBlockedIP(item)
+			if err != nil {
+				log.Printf("Skipping invalid blocklist entry: %v", err)
+				continue
+			}
+			if entry.ExpiresAt <= now {
+				expired = append(expired, entry)
+			} else {
+				active = append(active, entry)
+			}
+		}
+	}
+
+	// Keep the most recent blocks when an IP set limit is exceeded (each address family has its own set)
+	sort.Slice(active, func(i, j int) bool { return active[i].ExpiresAt > active[j].ExpiresAt })
+
+	ipv4 := make([]string, 0)
+	ipv6 := make([]string, 0)
+	dropped := 0
+	for _, entry := range active {
+		addr, err := netip.ParseAddr(entry.IP)
+		if err != nil {
+			continue
+		}
+		switch {
+		case addr.Is4() && len(ipv4) < cfg.MaxBlockedIPs:
+			ipv4 = append(ipv4, addr.String()+"/32")
+		case !addr.Is4() && len(ipv6) < cfg.MaxBlockedIPs:
+			ipv6 = append(ipv6, addr.String()+"/128")
+		default:
+			dropped++
+		}
+	}
+	if dropped > 0 {
+		log.Printf("⚠️  %d active IPs exceed MAX_BLOCKED_IPS=%d per IP set, keeping the most recent", dropped, cfg.MaxBlockedIPs)
+	}
+
+	if err := updateIPSet(ctx, cfg.IPv4SetName, cfg.IPv4SetID, ipv4); err != nil {
+		return err
+	}
+	if err := updateIPSet(ctx, cfg.IPv6SetName, cfg.IPv6SetID, ipv6); err != nil {
+		return err
+	}
+
+	// Expired entries are deleted only after the IP sets no longer contain them
+	for _, entry := range expired {
+		if err := deleteExpiredIP(ctx, entry, now); err != nil {
+			return fmt.Errorf("failed to delete expired entry %s: %w", entry.IP, err)
+		}
+		log.Printf("Unblocked %s (expired)", entry.IP)
+	}
+
+	log.Printf("IP sets synced: %d IPv4, %d IPv6, %d expired", len(ipv4), len(ipv6), len(expired))
+	return nil
+}
+
+// updateIPSet replaces the addresses of an IP set, retrying when another
+// update changed the lock token in between
+func updateIPSet(ctx context.Context, name, id string, addresses []string) error {
+	for attempt := 1; attempt <= maxUpdateAttempts; attempt++ {
+		current, err := wafClient.GetIPSet(ctx, &wafv2.GetIPSetInput{
+			Name:  aws.String(name),
+			Id:    aws.String(id),
+			Scope: waftypes.Scope(cfg.WAFScope),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get IP set %s: %w", name, err)
+		}
+
+		if sameAddresses(current.IPSet.Addresses, addresses) {
+			return nil
+		}
+
+		_, err = wafClient.UpdateIPSet(ctx, &wafv2.UpdateIPSetInput{
+			Name:        aws.String(name),
+			Id:          aws.String(id),
+			Scope:       waftypes.Scope(cfg.WAFScope),
+			Addresses:   addresses,
+			LockToken:   current.LockToken,
+			Description: current.IPSet.Description,
+		})
+		if err == nil {
+			return nil
+		}
+
+		var lockErr *waftypes.WAFOptimisticLockException
+		if !errors.As(err, &lockErr) {
+			return fmt.Errorf("failed to update IP set %s: %w", name, err)
+		}
+
+		log.Printf("IP set %s changed during update, retrying (%d/%d)", name, attempt, maxUpdateAttempts)
+		time.Sleep(time.Duration(attempt) * 200 * time.Millisecond)
+	}
+
+	return fmt.Errorf("failed to update IP set %s: lock token kept changing after %d attempts", name, maxUpdateAttempts)
+}
+
+// deleteExpiredIP removes an entry unless a newer finding extended its block meanwhile
+func deleteExpiredIP(ctx context.Context, entry blockedIP, now int64) error {
+	_, err := ddbClient.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(cfg.TableName),
+		Key: map[string]dynamodbtypes.AttributeValue{
+			"ip": &dynamodbtypes.AttributeValueMemberS{Value: entry.IP},
+		},
+		ConditionExpression: aws.String("expiresAt <= :now"),
+		ExpressionAttributeValues: map[string]dynamodbtypes.AttributeValue{
+			":now": &dynamodbtypes.AttributeValueMemberN{Value: strconv.FormatInt(now, 10)},
+		},
+	})
+
+	var conditionErr *dynamodbtypes.ConditionalCheckFailedException
+	if errors.As(err, &conditionErr) {
+		return nil
+	}
+	return err
+}
+
+// parseBlockedIP reads the ip and expiresAt attributes of a table item
+func parseBlockedIP(item map[string]dynamodbtypes.AttributeValue) (blockedIP, error) {
+	ip, ok := item["ip"].(*dynamodbtypes.AttributeValueMemberS)
+	if !ok {
+		return blockedIP{}, errors.New("missing ip attribute")
+	}
+
+	expiresAtValue, ok := item["expiresAt"].(*dynamodbtypes.AttributeValueMemberN)
+	if !ok {
+		return blockedIP{}, fmt.Errorf("missing expiresAt attribute for %s", ip.Value)
+	}
+
+	expiresAt, err := strconv.ParseInt(expiresAtValue.Value, 10, 64)
+	if err != nil {
+		return blockedIP{}, fmt.Errorf("invalid expiresAt for %s: %w", ip.Value, err)
+	}
+
+	return blockedIP{IP: ip.Value, ExpiresAt: expiresAt}, nil
+}
+
+// sameAddresses reports whether two address lists contain the same CIDRs
+func sameAddresses(current, desired []string) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+
+	set := make(map[string]bool, len(current))
+	for _, address := range current {
+		set[address] = true
+	}
+	for _, address := range desired {
+		if !set[address] {
+			return false
+		}
+	}
+	return true
+}
+
+// getEnv retrieves environment variable with fallback default
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvInt retrieves environment variable as integer with fallback default
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+func main() {
+	lambda.Start(handler)
+}
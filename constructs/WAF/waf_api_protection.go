@@ -39,125 +39,38 @@ func (s *WAFAPIProtectionStrategy) Build(scope constructs.Construct, id string,
 	// =============================================================================
 	// WEB ACL CONFIGURATION - API Protection
 	// =============================================================================
+	builder := newRuleBuilder(scope, id, props, "-API-WebACL")
 
-	// Determine scope (CLOUDFRONT or REGIONAL)
-	wafScope := "CLOUDFRONT"
-	if props.Scope == ScopeRegional {
-		wafScope = "REGIONAL"
-	}
-
-	// Default name
-	webACLName := props.Name
-	if webACLName == "" {
-		webACLName = id + "-API-WebACL"
-	}
-
-	// Build rules array
-	rules := make([]interface{}, 0)
-	priority := int64(0)
-
-	// =============================================================================
 	// RULE 1: Rate Limiting (default 10,000 req/5min for APIs)
 	// Higher threshold than web apps since APIs handle more legitimate traffic
-	// =============================================================================
 	rateLimitValue := int64(10000) // Default for APIs
 	if props.RateLimitRequests != nil && *props.RateLimitRequests > 0 {
 		rateLimitValue = *props.RateLimitRequests
 	}
+	builder.addRateLimit("APIRateLimitRule", rateLimitValue, nil)
 
-	rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-		Name:     jsii.String("APIRateLimitRule"),
-		Priority: jsii.Number(priority),
-		Statement: &awswafv2.CfnWebACL_StatementProperty{
-			RateBasedStatement: &awswafv2.CfnWebACL_RateBasedStatementProperty{
-				Limit:            jsii.Number(float64(rateLimitValue)),
-				AggregateKeyType: jsii.String("IP"),
-			},
-		},
-		Action: &awswafv2.CfnWebACL_RuleActionProperty{
-			Block: &awswafv2.CfnWebACL_BlockActionProperty{
-				CustomResponse: &awswafv2.CfnWebACL_CustomResponseProperty{
-					ResponseCode: jsii.Number(429), // Too Many Requests
-				},
-			},
-		},
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String("APIRateLimitRule"),
-		},
-	})
-	priority++
+	// Scoped rate limits (per path, method or header) from props.RateLimits
+	builder.addScopedRateLimits()
 
-	// =============================================================================
 	// RULE 2: Geo Blocking (if specified)
-	// =============================================================================
 	if len(props.GeoBlockCountries) > 0 {
-		countryCodes := make([]*string, len(props.GeoBlockCountries))
-		for i, code := range props.GeoBlockCountries {
-			countryCodes[i] = jsii.String(code)
-		}
-
-		rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-			Name:     jsii.String("APIGeoBlockingRule"),
-			Priority: jsii.Number(priority),
-			Statement: &awswafv2.CfnWebACL_StatementProperty{
-				GeoMatchStatement: &awswafv2.CfnWebACL_GeoMatchStatementProperty{
-					CountryCodes: &countryCodes,
-				},
-			},
-			Action: &awswafv2.CfnWebACL_RuleActionProperty{
-				Block: &awswafv2.CfnWebACL_BlockActionProperty{},
-			},
-			VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-				SampledRequestsEnabled:   jsii.Bool(true),
-				CloudWatchMetricsEnabled: jsii.Bool(true),
-				MetricName:               jsii.String("APIGeoBlockingRule"),
-			},
-		})
-		priority++
+		builder.addGeoBlock("APIGeoBlockingRule", props.GeoBlockCountries)
 	}
 
-	// =============================================================================
 	// RULE 3: IP Blocklist (if specified)
-	// =============================================================================
 	if len(props.BlockedIPs) > 0 {
-		ipSet := awswafv2.NewCfnIPSet(scope, jsii.String(id+"BlockedIPSet"), &awswafv2.CfnIPSetProps{
-			Name:             jsii.String(webACLName + "-BlockedIPs"),
-			Scope:            jsii.String(wafScope),
-			IpAddressVersion: jsii.String("IPV4"),
-			Addresses:        jsii.Strings(props.BlockedIPs...),
-			Description:      jsii.String("API blocked IP addresses"),
-		})
-
-		rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-			Name:     jsii.String("APIIPBlocklistRule"),
-			Priority: jsii.Number(priority),
-			Statement: &awswafv2.CfnWebACL_StatementProperty{
-				IpSetReferenceStatement: &awswafv2.CfnWebACL_IPSetReferenceStatementProperty{
-					Arn: ipSet.AttrArn(),
-				},
-			},
-			Action: &awswafv2.CfnWebACL_RuleActionProperty{
-				Block: &awswafv2.CfnWebACL_BlockActionProperty{},
-			},
-			VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-				SampledRequestsEnabled:   jsii.Bool(true),
-				CloudWatchMetricsEnabled: jsii.Bool(true),
-				MetricName:               jsii.String("APIIPBlocklistRule"),
-			},
-		})
-		priority++
+		builder.addIPSetRule("APIIPBlocklistRule", "BlockedIPSet", "-BlockedIPs", "API blocked IP addresses", props.BlockedIPs, RuleActionBlock)
 	}
 
+	// Dynamic blocklist fed by GuardDuty findings (if specified)
+	builder.addGuardDutyBlocklist()
+
 	// =============================================================================
 	// RULE 4: Request Size Constraint (protect against large payloads)
 	// Blocks requests with body > 8KB (typical API threshold)
 	// =============================================================================
-	rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-		Name:     jsii.String("APISizeConstraintRule"),
-		Priority: jsii.Number(priority),
-		Statement: &awswafv2.CfnWebACL_StatementProperty{
+	builder.addRule("APISizeConstraintRule",
+		&awswafv2.CfnWebACL_StatementProperty{
 			SizeConstraintStatement: &awswafv2.CfnWebACL_SizeConstraintStatementProperty{
 				FieldToMatch: &awswafv2.CfnWebACL_FieldToMatchProperty{
 					Body: &awswafv2.CfnWebACL_BodyProperty{
@@ -174,134 +87,24 @@ func (s *WAFAPIProtectionStrategy) Build(scope constructs.Construct, id string,
 				},
 			},
 		},
-		Action: &awswafv2.CfnWebACL_RuleActionProperty{
-			Block: &awswafv2.CfnWebACL_BlockActionProperty{
-				CustomResponse: &awswafv2.CfnWebACL_CustomResponseProperty{
-					ResponseCode: jsii.Number(413), // Payload Too Large
-				},
-			},
-		},
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String("APISizeConstraintRule"),
-		},
-	})
-	priority++
+		blockWithStatus(413), // Payload Too Large
+	)
 
 	// =============================================================================
 	// AWS MANAGED RULE GROUPS - API Focused
 	// =============================================================================
+	builder.addAWSManagedRuleGroups(
+		"AWSManagedRulesCommonRuleSet",
+		"AWSManagedRulesSQLiRuleSet",
+		"AWSManagedRulesKnownBadInputsRuleSet",
+		"AWSManagedRulesAmazonIpReputationList",
+	)
 
-	// RULE 5: AWS Managed Rules - Core Rule Set (OWASP Top 10)
-	rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-		Name:     jsii.String("AWSManagedRulesCommonRuleSet"),
-		Priority: jsii.Number(priority),
-		Statement: &awswafv2.CfnWebACL_StatementProperty{
-			ManagedRuleGroupStatement: &awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty{
-				VendorName: jsii.String("AWS"),
-				Name:       jsii.String("AWSManagedRulesCommonRuleSet"),
-			},
-		},
-		OverrideAction: &awswafv2.CfnWebACL_OverrideActionProperty{
-			None: map[string]interface{}{},
-		},
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String("AWSManagedRulesCommonRuleSet"),
-		},
-	})
-	priority++
-
-	// RULE 6: AWS Managed Rules - SQL Database (Critical for APIs)
-	rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-		Name:     jsii.String("AWSManagedRulesSQLiRuleSet"),
-		Priority: jsii.Number(priority),
-		Statement: &awswafv2.CfnWebACL_StatementProperty{
-			ManagedRuleGroupStatement: &awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty{
-				VendorName: jsii.String("AWS"),
-				Name:       jsii.String("AWSManagedRulesSQLiRuleSet"),
-			},
-		},
-		OverrideAction: &awswafv2.CfnWebACL_OverrideActionProperty{
-			None: map[string]interface{}{},
-		},
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String("AWSManagedRulesSQLiRuleSet"),
-		},
-	})
-	priority++
-
-	// RULE 7: AWS Managed Rules - Known Bad Inputs
-	rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-		Name:     jsii.String("AWSManagedRulesKnownBadInputsRuleSet"),
-		Priority: jsii.Number(priority),
-		Statement: &awswafv2.CfnWebACL_StatementProperty{
-			ManagedRuleGroupStatement: &awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty{
-				VendorName: jsii.String("AWS"),
-				Name:       jsii.String("AWSManagedRulesKnownBadInputsRuleSet"),
-			},
-		},
-		OverrideAction: &awswafv2.CfnWebACL_OverrideActionProperty{
-			None: map[string]interface{}{},
-		},
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String("AWSManagedRulesKnownBadInputsRuleSet"),
-		},
-	})
-	priority++
-
-	// RULE 8: AWS Managed Rules - Amazon IP Reputation List
-	rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-		Name:     jsii.String("AWSManagedRulesAmazonIpReputationList"),
-		Priority: jsii.Number(priority),
-		Statement: &awswafv2.CfnWebACL_StatementProperty{
-			ManagedRuleGroupStatement: &awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty{
-				VendorName: jsii.String("AWS"),
-				Name:       jsii.String("AWSManagedRulesAmazonIpReputationList"),
-			},
-		},
-		OverrideAction: &awswafv2.CfnWebACL_OverrideActionProperty{
-			None: map[string]interface{}{},
-		},
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String("AWSManagedRulesAmazonIpReputationList"),
-		},
-	})
+	// Account takeover / account creation fraud prevention (if configured)
+	builder.addFraudPreventionRuleGroups()
 
 	// =============================================================================
 	// CREATE WEB ACL
 	// =============================================================================
-
-	webACL := awswafv2.NewCfnWebACL(scope, jsii.String(id), &awswafv2.CfnWebACLProps{
-		Name:  jsii.String(webACLName),
-		Scope: jsii.String(wafScope),
-
-		// Default action: Allow
-		DefaultAction: &awswafv2.CfnWebACL_DefaultActionProperty{
-			Allow: &awswafv2.CfnWebACL_AllowActionProperty{},
-		},
-
-		// Rules array
-		Rules: &rules,
-
-		// Visibility configuration
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String(webACLName + "-Metrics"),
-		},
-
-		// Description
-		Description: jsii.String("API Protection WAF for " + webACLName + " - SQL Injection, Rate Limiting, Body Inspection"),
-	})
-
-	return webACL
+	return builder.build("API Protection WAF for " + builder.webACLName + " - SQL Injection, Rate Limiting, Body Inspection")
 }
@@ -0,0 +1,140 @@
+package waf
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsapigateway"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsappsync"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awscognito"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awselasticloadbalancingv2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awswafv2"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// cloudFrontWebACLRegion is the only region where CLOUDFRONT-scoped Web ACLs can be created
+const cloudFrontWebACLRegion = "us-east-1"
+
+// WebACLAssociationProps lists the regional resources protected by a Web ACL
+type WebACLAssociationProps struct {
+	// Required: REGIONAL Web ACL (e.g. from NewWebApplicationFirewallFactory with ScopeRegional)
+	WebACL awswafv2.CfnWebACL
+
+	// Optional: Application Load Balancers
+	LoadBalancers []awselasticloadbalancingv2.IApplicationLoadBalancer
+
+	// Optional: API Gateway REST API stages (e.g. api.DeploymentStage())
+	ApiStages []awsapigateway.IStage
+
+	// Optional: AppSync GraphQL APIs
+	AppSyncApis []awsappsync.IGraphqlApi
+
+	// Optional: Cognito user pools (hosted UI and auth endpoints)
+	UserPools []awscognito.IUserPool
+
+	// Optional: ARNs of other supported resources (App Runner, Verified Access, Amplify)
+	ResourceArns []string
+}
+
+// associatedResource is a resource to protect, with its kind used in construct ids
+type associatedResource struct {
+	kind string
+	arn  *string
+}
+
+// NewWebACLAssociations associates a REGIONAL Web ACL with ALBs, API Gateway
+// stages, AppSync APIs and Cognito user pools.
+//
+// CloudFront distributions are not associated this way: pass the Web ACL ARN to
+// the distribution (CloudFrontPropertiesV2.WebAclArn) instead.
+//
+// Example usage:
+//
+//	webACL := waf.NewWebApplicationFirewallFactory(stack, "ApiWAF", waf.WAFFactoryProps{
+//	    Scope:       waf.ScopeRegional,
+//	    ProfileType: waf.ProfileTypeAPIProtection,
+//	})
+//	waf.NewWebACLAssociations(stack, "ApiWAF", waf.WebACLAssociationProps{
+//	    WebACL:        webACL,
+//	    LoadBalancers: []awselasticloadbalancingv2.IApplicationLoadBalancer{alb},
+//	    ApiStages:     []awsapigateway.IStage{api.DeploymentStage()},
+//	})
+func NewWebACLAssociations(scope constructs.Construct, id string, props WebACLAssociationProps) []awswafv2.CfnWebACLAssociation {
+	if props.WebACL == nil {
+		panic("WebACLAssociationProps.WebACL is required")
+	}
+
+	if aclScope := props.WebACL.Scope(); aclScope == nil || *aclScope != "REGIONAL" {
+		panic(fmt.Sprintf("Web ACL %s must have REGIONAL scope to be associated with ALBs, API Gateway, AppSync or Cognito "+
+			"(CLOUDFRONT Web ACLs are attached through the distribution's WebAclArn)", *props.WebACL.Name()))
+	}
+
+	stack := awscdk.Stack_Of(scope)
+	resources := make([]associatedResource, 0)
+	add := func(kind string, arn *string) {
+		resources = append(resources, associatedResource{kind: kind, arn: arn})
+	}
+
+	for _, loadBalancer := range props.LoadBalancers {
+		add("LoadBalancer", loadBalancer.LoadBalancerArn())
+	}
+
+	for _, stage := range props.ApiStages {
+		// arn:aws:apigateway:<region>::/restapis/<api-id>/stages/<stage>
+		add("ApiStage", stack.FormatArn(&awscdk.ArnComponents{
+			Service:      jsii.String("apigateway"),
+			Account:      jsii.String(""),
+			Resource:     jsii.String("/restapis"),
+			ResourceName: jsii.String(*stage.RestApi().RestApiId() + "/stages/" + *stage.StageName()),
+			ArnFormat:    awscdk.ArnFormat_SLASH_RESOURCE_NAME,
+		}))
+	}
+
+	for _, api := range props.AppSyncApis {
+		add("AppSyncApi", api.Arn())
+	}
+
+	for _, userPool := range props.UserPools {
+		add("UserPool", userPool.UserPoolArn())
+	}
+
+	for _, arn := range props.ResourceArns {
+		add("Resource", jsii.String(arn))
+	}
+
+	if len(resources) == 0 {
+		panic(fmt.Sprintf("NewWebACLAssociations %s: no resources to associate", id))
+	}
+
+	// Construct ids are numbered per resource kind: <id>LoadBalancerAssociation0, ...
+	counters := make(map[string]int)
+	associations := make([]awswafv2.CfnWebACLAssociation, 0, len(resources))
+	for _, resource := range resources {
+		associationID := fmt.Sprintf("%s%sAssociation%d", id, resource.kind, counters[resource.kind])
+		counters[resource.kind]++
+
+		associations = append(associations, awswafv2.NewCfnWebACLAssociation(scope, jsii.String(associationID), &awswafv2.CfnWebACLAssociationProps{
+			WebAclArn:   props.WebACL.AttrArn(),
+			ResourceArn: resource.arn,
+		}))
+	}
+
+	return associations
+}
+
+// validateCloudFrontRegion fails synthesis when a CLOUDFRONT-scoped Web ACL is
+// defined in a stack outside us-east-1. Environment-agnostic stacks (unresolved
+// region) are not checked
+func validateCloudFrontRegion(scope constructs.Construct, id string) {
+	region := awscdk.Stack_Of(scope).Region()
+	if awscdk.Token_IsUnresolved(region) {
+		return
+	}
+
+	if *region != cloudFrontWebACLRegion {
+		panic(fmt.Sprintf("WAF %s: CLOUDFRONT-scoped Web ACLs must be created in %s, but the stack region is %s. "+
+			"Deploy the Web ACL in a us-east-1 stack and reference its ARN from this region "+
+			"(CrossRegionReferences), or use ScopeRegional for ALB/API Gateway", id, cloudFrontWebACLRegion, *region))
+	}
+}
@@ -1,6 +1,8 @@
 package waf
 
 import (
+	"fmt"
+
 	"github.com/aws/aws-cdk-go/awscdk/v2/awswafv2"
 	"github.com/aws/constructs-go/constructs/v10"
 	"github.com/aws/jsii-runtime-go"
@@ -39,304 +41,98 @@ import (
 // Use only when bot protection is critical to business operations.
 type WAFBotControlStrategy struct{}
 
+// botControlSignalRules are the Bot Control rules that flag suspicious traffic
+// (as opposed to identified bot categories). BotControlAction applies to them
+var botControlSignalRules = []string{
+	"SignalAutomatedBrowser",
+	"SignalKnownBotDataCenter",
+	"SignalNonBrowserUserAgent",
+}
+
 // Build creates a WAF Web ACL configured with advanced bot control
 func (s *WAFBotControlStrategy) Build(scope constructs.Construct, id string, props WAFFactoryProps) awswafv2.CfnWebACL {
 
 	// =============================================================================
 	// WEB ACL CONFIGURATION - Bot Control Protection
 	// =============================================================================
+	builder := newRuleBuilder(scope, id, props, "-BotControl-WebACL")
 
-	// Determine scope (CLOUDFRONT or REGIONAL)
-	wafScope := "CLOUDFRONT"
-	if props.Scope == ScopeRegional {
-		wafScope = "REGIONAL"
-	}
-
-	// Default name
-	webACLName := props.Name
-	if webACLName == "" {
-		webACLName = id + "-BotControl-WebACL"
-	}
-
-	// Build rules array
-	rules := make([]interface{}, 0)
-	priority := int64(0)
-
-	// =============================================================================
 	// RULE 1: Strict Rate Limiting for Bot Protection
 	// Lower threshold to catch aggressive bots
-	// =============================================================================
 	rateLimitValue := int64(500) // Stricter default for bot control
 	if props.RateLimitRequests != nil && *props.RateLimitRequests > 0 {
 		rateLimitValue = *props.RateLimitRequests
 	}
+	builder.addRateLimit("BotControlRateLimitRule", rateLimitValue, nil)
 
-	rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-		Name:     jsii.String("BotControlRateLimitRule"),
-		Priority: jsii.Number(priority),
-		Statement: &awswafv2.CfnWebACL_StatementProperty{
-			RateBasedStatement: &awswafv2.CfnWebACL_RateBasedStatementProperty{
-				Limit:            jsii.Number(float64(rateLimitValue)),
-				AggregateKeyType: jsii.String("IP"),
-			},
-		},
-		Action: &awswafv2.CfnWebACL_RuleActionProperty{
-			Block: &awswafv2.CfnWebACL_BlockActionProperty{
-				CustomResponse: &awswafv2.CfnWebACL_CustomResponseProperty{
-					ResponseCode: jsii.Number(429),
-				},
-			},
-		},
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String("BotControlRateLimitRule"),
-		},
-	})
-	priority++
+	// Scoped rate limits (per path, method or header) from props.RateLimits
+	builder.addScopedRateLimits()
 
-	// =============================================================================
 	// RULE 2: Geo Blocking (if specified)
-	// =============================================================================
 	if len(props.GeoBlockCountries) > 0 {
-		countryCodes := make([]*string, len(props.GeoBlockCountries))
-		for i, code := range props.GeoBlockCountries {
-			countryCodes[i] = jsii.String(code)
-		}
-
-		rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-			Name:     jsii.String("BotControlGeoBlockingRule"),
-			Priority: jsii.Number(priority),
-			Statement: &awswafv2.CfnWebACL_StatementProperty{
-				GeoMatchStatement: &awswafv2.CfnWebACL_GeoMatchStatementProperty{
-					CountryCodes: &countryCodes,
-				},
-			},
-			Action: &awswafv2.CfnWebACL_RuleActionProperty{
-				Block: &awswafv2.CfnWebACL_BlockActionProperty{},
-			},
-			VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-				SampledRequestsEnabled:   jsii.Bool(true),
-				CloudWatchMetricsEnabled: jsii.Bool(true),
-				MetricName:               jsii.String("BotControlGeoBlockingRule"),
-			},
-		})
-		priority++
+		builder.addGeoBlock("BotControlGeoBlockingRule", props.GeoBlockCountries)
 	}
 
-	// =============================================================================
 	// RULE 3: IP Blocklist (if specified)
-	// =============================================================================
 	if len(props.BlockedIPs) > 0 {
-		ipSet := awswafv2.NewCfnIPSet(scope, jsii.String(id+"BlockedIPSet"), &awswafv2.CfnIPSetProps{
-			Name:             jsii.String(webACLName + "-BlockedIPs"),
-			Scope:            jsii.String(wafScope),
-			IpAddressVersion: jsii.String("IPV4"),
-			Addresses:        jsii.Strings(props.BlockedIPs...),
-			Description:      jsii.String("Bot Control blocked IP addresses"),
-		})
-
-		rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-			Name:     jsii.String("BotControlIPBlocklistRule"),
-			Priority: jsii.Number(priority),
-			Statement: &awswafv2.CfnWebACL_StatementProperty{
-				IpSetReferenceStatement: &awswafv2.CfnWebACL_IPSetReferenceStatementProperty{
-					Arn: ipSet.AttrArn(),
-				},
-			},
-			Action: &awswafv2.CfnWebACL_RuleActionProperty{
-				Block: &awswafv2.CfnWebACL_BlockActionProperty{},
-			},
-			VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-				SampledRequestsEnabled:   jsii.Bool(true),
-				CloudWatchMetricsEnabled: jsii.Bool(true),
-				MetricName:               jsii.String("BotControlIPBlocklistRule"),
-			},
-		})
-		priority++
+		builder.addIPSetRule("BotControlIPBlocklistRule", "BlockedIPSet", "-BlockedIPs", "Bot Control blocked IP addresses", props.BlockedIPs, RuleActionBlock)
 	}
 
+	// Dynamic blocklist fed by GuardDuty findings (if specified)
+	builder.addGuardDutyBlocklist()
+
 	// =============================================================================
 	// AWS MANAGED RULE GROUPS - Bot Control Stack
 	// =============================================================================
 
 	// RULE 4: AWS Managed Rules - Bot Control (PREMIUM)
 	// This is the main bot detection engine
-	rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-		Name:     jsii.String("AWSManagedRulesBotControlRuleSet"),
-		Priority: jsii.Number(priority),
-		Statement: &awswafv2.CfnWebACL_StatementProperty{
-			ManagedRuleGroupStatement: &awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty{
-				VendorName: jsii.String("AWS"),
-				Name:       jsii.String("AWSManagedRulesBotControlRuleSet"),
-				// ManagedRuleGroupConfigs can be used to configure bot control behavior
-				// Example: Configure to allow verified bots (Google, Bing, etc.)
-				ManagedRuleGroupConfigs: &[]*awswafv2.CfnWebACL_ManagedRuleGroupConfigProperty{
-					{
-						// AWS Bot Control configuration
-						// InspectionLevel can be "COMMON" or "TARGETED"
-						AwsManagedRulesBotControlRuleSet: &awswafv2.CfnWebACL_AWSManagedRulesBotControlRuleSetProperty{
-							InspectionLevel: jsii.String("COMMON"), // COMMON is less expensive than TARGETED
-						},
-					},
-				},
-			},
-		},
-		OverrideAction: &awswafv2.CfnWebACL_OverrideActionProperty{
-			None: map[string]interface{}{},
-		},
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String("AWSManagedRulesBotControlRuleSet"),
-		},
-	})
-	priority++
-
-	// RULE 5: AWS Managed Rules - Core Rule Set (OWASP Top 10)
-	rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-		Name:     jsii.String("AWSManagedRulesCommonRuleSet"),
-		Priority: jsii.Number(priority),
-		Statement: &awswafv2.CfnWebACL_StatementProperty{
-			ManagedRuleGroupStatement: &awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty{
-				VendorName: jsii.String("AWS"),
-				Name:       jsii.String("AWSManagedRulesCommonRuleSet"),
+	botControl := awsManagedRuleGroup("AWSManagedRulesBotControlRuleSet")
+	botControl.ManagedRuleGroupConfigs = &[]*awswafv2.CfnWebACL_ManagedRuleGroupConfigProperty{
+		{
+			// InspectionLevel can be "COMMON" or "TARGETED"
+			AwsManagedRulesBotControlRuleSet: &awswafv2.CfnWebACL_AWSManagedRulesBotControlRuleSetProperty{
+				InspectionLevel: jsii.String("COMMON"), // COMMON is less expensive than TARGETED
 			},
 		},
-		OverrideAction: &awswafv2.CfnWebACL_OverrideActionProperty{
-			None: map[string]interface{}{},
-		},
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String("AWSManagedRulesCommonRuleSet"),
-		},
-	})
-	priority++
+	}
+	// Suspicious-traffic signals can challenge (or CAPTCHA) instead of blocking:
+	// real browsers pass silently and automation is stopped by the token check
+	switch props.BotControlAction {
+	case "", RuleActionBlock:
+	case RuleActionChallenge, RuleActionCaptcha, RuleActionCount:
+		overrides := make([]interface{}, len(botControlSignalRules))
+		for i, rule := range botControlSignalRules {
+			overrides[i] = &awswafv2.CfnWebACL_RuleActionOverrideProperty{
+				Name:        jsii.String(rule),
+				ActionToUse: ruleAction(props.BotControlAction),
+			}
+		}
+		botControl.RuleActionOverrides = &overrides
+	default:
+		panic(fmt.Sprintf("BotControlAction must be BLOCK, CHALLENGE, CAPTCHA or COUNT, got %s", props.BotControlAction))
+	}
 
-	// RULE 6: AWS Managed Rules - SQL Database Protection
-	rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-		Name:     jsii.String("AWSManagedRulesSQLiRuleSet"),
-		Priority: jsii.Number(priority),
-		Statement: &awswafv2.CfnWebACL_StatementProperty{
-			ManagedRuleGroupStatement: &awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty{
-				VendorName: jsii.String("AWS"),
-				Name:       jsii.String("AWSManagedRulesSQLiRuleSet"),
-			},
-		},
-		OverrideAction: &awswafv2.CfnWebACL_OverrideActionProperty{
-			None: map[string]interface{}{},
-		},
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String("AWSManagedRulesSQLiRuleSet"),
-		},
-	})
-	priority++
+	builder.addManagedRuleGroup("AWSManagedRulesBotControlRuleSet", botControl, false)
 
-	// RULE 7: AWS Managed Rules - Known Bad Inputs
-	rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-		Name:     jsii.String("AWSManagedRulesKnownBadInputsRuleSet"),
-		Priority: jsii.Number(priority),
-		Statement: &awswafv2.CfnWebACL_StatementProperty{
-			ManagedRuleGroupStatement: &awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty{
-				VendorName: jsii.String("AWS"),
-				Name:       jsii.String("AWSManagedRulesKnownBadInputsRuleSet"),
-			},
-		},
-		OverrideAction: &awswafv2.CfnWebACL_OverrideActionProperty{
-			None: map[string]interface{}{},
-		},
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String("AWSManagedRulesKnownBadInputsRuleSet"),
-		},
-	})
-	priority++
-
-	// RULE 8: AWS Managed Rules - Amazon IP Reputation List
-	rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-		Name:     jsii.String("AWSManagedRulesAmazonIpReputationList"),
-		Priority: jsii.Number(priority),
-		Statement: &awswafv2.CfnWebACL_StatementProperty{
-			ManagedRuleGroupStatement: &awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty{
-				VendorName: jsii.String("AWS"),
-				Name:       jsii.String("AWSManagedRulesAmazonIpReputationList"),
-			},
-		},
-		OverrideAction: &awswafv2.CfnWebACL_OverrideActionProperty{
-			None: map[string]interface{}{},
-		},
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String("AWSManagedRulesAmazonIpReputationList"),
-		},
-	})
-	priority++
+	// RULES 5-9: Baseline protections (OWASP, SQL, bad inputs, IP reputation, anonymous IPs)
+	builder.addAWSManagedRuleGroups(
+		"AWSManagedRulesCommonRuleSet",
+		"AWSManagedRulesSQLiRuleSet",
+		"AWSManagedRulesKnownBadInputsRuleSet",
+		"AWSManagedRulesAmazonIpReputationList",
+		"AWSManagedRulesAnonymousIpList",
+	)
 
-	// RULE 9: AWS Managed Rules - Anonymous IP List (blocks VPNs, Tor, proxies)
-	rules = append(rules, &awswafv2.CfnWebACL_RuleProperty{
-		Name:     jsii.String("AWSManagedRulesAnonymousIpList"),
-		Priority: jsii.Number(priority),
-		Statement: &awswafv2.CfnWebACL_StatementProperty{
-			ManagedRuleGroupStatement: &awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty{
-				VendorName: jsii.String("AWS"),
-				Name:       jsii.String("AWSManagedRulesAnonymousIpList"),
-			},
-		},
-		OverrideAction: &awswafv2.CfnWebACL_OverrideActionProperty{
-			None: map[string]interface{}{},
-		},
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String("AWSManagedRulesAnonymousIpList"),
-		},
-	})
+	// Account takeover / account creation fraud prevention (if configured)
+	builder.addFraudPreventionRuleGroups()
 
 	// =============================================================================
 	// CREATE WEB ACL WITH CAPTCHA CONFIGURATION
+	// 5 minutes immunity after solving a CAPTCHA or challenge, unless
+	// CaptchaImmunityTime / ChallengeImmunityTime are set
 	// =============================================================================
+	builder.defaultImmunityTime = 300
 
-	webACL := awswafv2.NewCfnWebACL(scope, jsii.String(id), &awswafv2.CfnWebACLProps{
-		Name:  jsii.String(webACLName),
-		Scope: jsii.String(wafScope),
-
-		// Default action: Allow (bots are challenged/blocked by Bot Control rule)
-		DefaultAction: &awswafv2.CfnWebACL_DefaultActionProperty{
-			Allow: &awswafv2.CfnWebACL_AllowActionProperty{},
-		},
-
-		// Rules array
-		Rules: &rules,
-
-		// CAPTCHA configuration (optional, for additional bot verification)
-		CaptchaConfig: &awswafv2.CfnWebACL_CaptchaConfigProperty{
-			ImmunityTimeProperty: &awswafv2.CfnWebACL_ImmunityTimePropertyProperty{
-				ImmunityTime: jsii.Number(300), // 5 minutes immunity after solving CAPTCHA
-			},
-		},
-
-		// Challenge configuration (similar to CAPTCHA but less intrusive)
-		ChallengeConfig: &awswafv2.CfnWebACL_ChallengeConfigProperty{
-			ImmunityTimeProperty: &awswafv2.CfnWebACL_ImmunityTimePropertyProperty{
-				ImmunityTime: jsii.Number(300), // 5 minutes immunity
-			},
-		},
-
-		// Visibility configuration
-		VisibilityConfig: &awswafv2.CfnWebACL_VisibilityConfigProperty{
-			SampledRequestsEnabled:   jsii.Bool(true),
-			CloudWatchMetricsEnabled: jsii.Bool(true),
-			MetricName:               jsii.String(webACLName + "-Metrics"),
-		},
-
-		// Description
-		Description: jsii.String("Bot Control WAF for " + webACLName + " - Advanced Bot Detection with ML, CAPTCHA, and OWASP Protection"),
-	})
-
-	return webACL
+	return builder.build("Bot Control WAF for " + builder.webACLName + " - Advanced Bot Detection with ML, CAPTCHA, and OWASP Protection")
 }
@@ -0,0 +1,499 @@
+package waf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awswafv2"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// RuleAction defines the action a rule applies when it matches
+type RuleAction string
+
+const (
+	// RuleActionAllow allows the request and stops evaluation
+	RuleActionAllow RuleAction = "ALLOW"
+
+	// RuleActionBlock blocks the request (403 unless the rule sets another status)
+	RuleActionBlock RuleAction = "BLOCK"
+
+	// RuleActionCount only counts the match and continues evaluation
+	RuleActionCount RuleAction = "COUNT"
+
+	// RuleActionCaptcha requires the client to solve a CAPTCHA puzzle
+	RuleActionCaptcha RuleAction = "CAPTCHA"
+
+	// RuleActionChallenge runs a silent browser challenge
+	RuleActionChallenge RuleAction = "CHALLENGE"
+)
+
+// MatchField defines the part of the request inspected by byte/regex match rules
+type MatchField string
+
+const (
+	// MatchFieldURIPath inspects the URI path (e.g. /api/login)
+	MatchFieldURIPath MatchField = "URI_PATH"
+
+	// MatchFieldQueryString inspects the whole query string
+	MatchFieldQueryString MatchField = "QUERY_STRING"
+
+	// MatchFieldHeader inspects a single header (requires FieldName)
+	MatchFieldHeader MatchField = "HEADER"
+
+	// MatchFieldMethod inspects the HTTP method
+	MatchFieldMethod MatchField = "METHOD"
+
+	// MatchFieldBody inspects the request body (first 8KB, 16KB on CloudFront)
+	MatchFieldBody MatchField = "BODY"
+
+	// MatchFieldSingleQueryArgument inspects a single query argument (requires FieldName)
+	MatchFieldSingleQueryArgument MatchField = "SINGLE_QUERY_ARGUMENT"
+)
+
+// CustomRule is a declarative Web ACL rule for ProfileTypeCustom.
+// Rules are evaluated in the order they are declared and priorities are assigned
+// automatically. Exactly one statement (ManagedRuleGroup, RateBased, Geo, IPSet,
+// ByteMatch, RegexMatch or LabelMatch) must be set.
+type CustomRule struct {
+	// Required: Rule name (also used as CloudWatch metric name)
+	Name string
+
+	// Action when the rule matches (default BLOCK).
+	// For managed rule groups only COUNT is accepted (count-only mode); leave
+	// empty to use the actions defined by the group
+	Action RuleAction
+
+	// Optional (BLOCK): Status code of the block response (default 403, 429 for rate-based rules)
+	ResponseStatusCode int64
+
+	// Optional (BLOCK): Key of a body in WAFFactoryProps.CustomResponseBodies
+	ResponseBodyKey string
+
+	// Optional: Match requests that do NOT satisfy the statement
+	// (not supported for managed rule groups and rate-based rules)
+	Negate bool
+
+	ManagedRuleGroup *ManagedRuleGroupRule
+	RateBased        *RateBasedRule
+	Geo              *GeoRule
+	IPSet            *IPSetRule
+	ByteMatch        *ByteMatchRule
+	RegexMatch       *RegexMatchRule
+	LabelMatch       *LabelMatchRule
+}
+
+// ManagedRuleGroupRule references a managed rule group
+type ManagedRuleGroupRule struct {
+	// Optional: Vendor name (default "AWS")
+	Vendor string
+
+	// Required: Rule group name (e.g. "AWSManagedRulesCommonRuleSet")
+	Name string
+
+	// Optional: Pinned version (e.g. "Version_1.10"). Default: the vendor's default version
+	Version string
+
+	// Optional: Rules of the group that only count instead of applying their action
+	ExcludedRules []string
+}
+
+// RateBasedRule limits the requests per IP in the evaluation window
+type RateBasedRule struct {
+	// Required: Maximum requests per IP in the evaluation window (minimum 10)
+	Limit int64
+
+	// Optional: Evaluation window in seconds: 60, 120, 300 or 600 (default 300)
+	EvaluationWindowSec int64
+
+	// Optional: Only count requests that match this statement
+	ScopeDown *ByteMatchRule
+}
+
+// GeoRule matches requests from the given countries
+type GeoRule struct {
+	// Required: ISO 3166-1 alpha-2 country codes
+	CountryCodes []string
+}
+
+// IPSetRule matches requests from the given addresses.
+// Set Addresses to create an IP set, or Arn to reference an existing one
+type IPSetRule struct {
+	// IP addresses in CIDR notation
+	Addresses []string
+
+	// Optional: "IPV4" (default) or "IPV6"
+	IPAddressVersion string
+
+	// ARN of an existing IP set
+	Arn string
+}
+
+// ByteMatchRule matches a string in a part of the request
+type ByteMatchRule struct {
+	// Required: Part of the request to inspect
+	Field MatchField
+
+	// Header or query argument name (HEADER and SINGLE_QUERY_ARGUMENT)
+	FieldName string
+
+	// Required: String to search for
+	SearchString string
+
+	// Optional: EXACTLY, STARTS_WITH, ENDS_WITH, CONTAINS (default) or CONTAINS_WORD
+	PositionalConstraint string
+
+	// Optional: Transformations applied before matching (e.g. "LOWERCASE", "URL_DECODE"). Default "NONE"
+	TextTransformations []string
+}
+
+// RegexMatchRule matches a regular expression in a part of the request
+type RegexMatchRule struct {
+	// Required: Part of the request to inspect
+	Field MatchField
+
+	// Header or query argument name (HEADER and SINGLE_QUERY_ARGUMENT)
+	FieldName string
+
+	// Required: Regular expression
+	Pattern string
+
+	// Optional: Transformations applied before matching. Default "NONE"
+	TextTransformations []string
+}
+
+// LabelMatchRule matches labels added by rules evaluated earlier
+// (e.g. "awswaf:managed:aws:bot-control:bot:category:http_library")
+type LabelMatchRule struct {
+	// Required: Label or namespace
+	Key string
+
+	// Optional: Key is a namespace (matches every label under it)
+	Namespace bool
+}
+
+// WAFCustomStrategy implements a fully declarative Web ACL
+// The rules come from WAFFactoryProps.CustomRules and are added in order, so
+// the declaration order is the evaluation order
+//
+// Use Cases:
+// - Workloads whose rules don't fit any predefined profile
+// - Pinning managed rule group versions and excluding noisy rules
+// - Label-based rules on top of Bot Control or other managed groups
+//
+// Cost Estimate:
+// - Web ACL: $5/month
+// - $1/month per rule (managed rule groups may add per-request fees)
+// - Requests: $0.60 per 1M requests
+type WAFCustomStrategy struct{}
+
+// Build creates a WAF Web ACL from the declarative rule list
+func (s *WAFCustomStrategy) Build(scope constructs.Construct, id string, props WAFFactoryProps) awswafv2.CfnWebACL {
+	validateCustomRules(props.CustomRules)
+
+	builder := newRuleBuilder(scope, id, props, "-Custom-WebACL")
+
+	switch props.DefaultAction {
+	case "", RuleActionAllow:
+		builder.defaultAction = RuleActionAllow
+	case RuleActionBlock:
+		builder.defaultAction = RuleActionBlock
+	default:
+		panic(fmt.Sprintf("Custom WAF: DefaultAction must be ALLOW or BLOCK, got %s", props.DefaultAction))
+	}
+
+	// Scoped rate limits from props.RateLimits are evaluated before the custom rules
+	builder.addScopedRateLimits()
+
+	// Dynamic blocklist fed by GuardDuty findings (if specified)
+	builder.addGuardDutyBlocklist()
+
+	for _, rule := range props.CustomRules {
+		addCustomRule(builder, rule)
+	}
+
+	// Account takeover / account creation fraud prevention (if configured)
+	builder.addFraudPreventionRuleGroups()
+
+	return builder.build(fmt.Sprintf("Custom WAF for %s - %d declarative rules", builder.webACLName, len(props.CustomRules)))
+}
+
+// addCustomRule converts a declarative rule and appends it to the builder
+func addCustomRule(builder *ruleBuilder, rule CustomRule) {
+	if rule.ManagedRuleGroup != nil {
+		builder.addManagedRuleGroup(rule.Name, managedRuleGroupStatement(rule.ManagedRuleGroup), rule.Action == RuleActionCount)
+		return
+	}
+
+	var statement *awswafv2.CfnWebACL_StatementProperty
+	action := ruleAction(rule.Action)
+
+	if rule.Action == "" || rule.Action == RuleActionBlock {
+		statusCode := rule.ResponseStatusCode
+		if statusCode == 0 && rule.RateBased != nil {
+			statusCode = 429 // Too Many Requests
+		}
+		if statusCode != 0 || rule.ResponseBodyKey != "" {
+			action = builder.blockWithResponse(statusCode, rule.ResponseBodyKey)
+		}
+	}
+
+	switch {
+	case rule.RateBased != nil:
+		statement = rateBasedStatement(rule.RateBased)
+
+	case rule.Geo != nil:
+		statement = &awswafv2.CfnWebACL_StatementProperty{
+			GeoMatchStatement: &awswafv2.CfnWebACL_GeoMatchStatementProperty{
+				CountryCodes: jsii.Strings(rule.Geo.CountryCodes...),
+			},
+		}
+
+	case rule.IPSet != nil:
+		arn := jsii.String(rule.IPSet.Arn)
+		if rule.IPSet.Arn == "" {
+			ipAddressVersion := rule.IPSet.IPAddressVersion
+			if ipAddressVersion == "" {
+				ipAddressVersion = "IPV4"
+			}
+			ipSet := builder.newIPSet(rule.Name+"IPSet", "-"+rule.Name, "IP addresses for rule "+rule.Name, ipAddressVersion, rule.IPSet.Addresses)
+			arn = ipSet.AttrArn()
+		}
+		statement = &awswafv2.CfnWebACL_StatementProperty{
+			IpSetReferenceStatement: &awswafv2.CfnWebACL_IPSetReferenceStatementProperty{
+				Arn: arn,
+			},
+		}
+
+	case rule.ByteMatch != nil:
+		statement = &awswafv2.CfnWebACL_StatementProperty{
+			ByteMatchStatement: byteMatchStatement(rule.ByteMatch),
+		}
+
+	case rule.RegexMatch != nil:
+		statement = &awswafv2.CfnWebACL_StatementProperty{
+			RegexMatchStatement: &awswafv2.CfnWebACL_RegexMatchStatementProperty{
+				FieldToMatch:        fieldToMatch(rule.RegexMatch.Field, rule.RegexMatch.FieldName),
+				RegexString:         jsii.String(rule.RegexMatch.Pattern),
+				TextTransformations: textTransformations(rule.RegexMatch.TextTransformations),
+			},
+		}
+
+	case rule.LabelMatch != nil:
+		labelScope := "LABEL"
+		if rule.LabelMatch.Namespace {
+			labelScope = "NAMESPACE"
+		}
+		statement = &awswafv2.CfnWebACL_StatementProperty{
+			LabelMatchStatement: &awswafv2.CfnWebACL_LabelMatchStatementProperty{
+				Key:   jsii.String(rule.LabelMatch.Key),
+				Scope: jsii.String(labelScope),
+			},
+		}
+	}
+
+	if rule.Negate {
+		statement = notStatement(statement)
+	}
+
+	builder.addRule(rule.Name, statement, action)
+}
+
+// managedRuleGroupStatement builds the managed rule group reference with its
+// pinned version and excluded rules (overridden to COUNT)
+func managedRuleGroupStatement(group *ManagedRuleGroupRule) *awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty {
+	vendor := group.Vendor
+	if vendor == "" {
+		vendor = "AWS"
+	}
+
+	statement := &awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty{
+		VendorName: jsii.String(vendor),
+		Name:       jsii.String(group.Name),
+	}
+
+	if group.Version != "" {
+		statement.Version = jsii.String(group.Version)
+	}
+
+	if len(group.ExcludedRules) > 0 {
+		overrides := make([]interface{}, 0, len(group.ExcludedRules))
+		for _, excludedRule := range group.ExcludedRules {
+			overrides = append(overrides, &awswafv2.CfnWebACL_RuleActionOverrideProperty{
+				Name:        jsii.String(excludedRule),
+				ActionToUse: ruleAction(RuleActionCount),
+			})
+		}
+		statement.RuleActionOverrides = &overrides
+	}
+
+	return statement
+}
+
+// rateBasedStatement builds a rate-based statement aggregated by IP
+func rateBasedStatement(rateBased *RateBasedRule) *awswafv2.CfnWebACL_StatementProperty {
+	statement := &awswafv2.CfnWebACL_RateBasedStatementProperty{
+		Limit:            jsii.Number(float64(rateBased.Limit)),
+		AggregateKeyType: jsii.String("IP"),
+	}
+
+	if rateBased.EvaluationWindowSec > 0 {
+		statement.EvaluationWindowSec = jsii.Number(float64(rateBased.EvaluationWindowSec))
+	}
+
+	if rateBased.ScopeDown != nil {
+		statement.ScopeDownStatement = &awswafv2.CfnWebACL_StatementProperty{
+			ByteMatchStatement: byteMatchStatement(rateBased.ScopeDown),
+		}
+	}
+
+	return &awswafv2.CfnWebACL_StatementProperty{
+		RateBasedStatement: statement,
+	}
+}
+
+// byteMatchStatement builds a byte match statement (default positional constraint CONTAINS)
+func byteMatchStatement(byteMatch *ByteMatchRule) *awswafv2.CfnWebACL_ByteMatchStatementProperty {
+	positionalConstraint := byteMatch.PositionalConstraint
+	if positionalConstraint == "" {
+		positionalConstraint = "CONTAINS"
+	}
+
+	return &awswafv2.CfnWebACL_ByteMatchStatementProperty{
+		FieldToMatch:         fieldToMatch(byteMatch.Field, byteMatch.FieldName),
+		PositionalConstraint: jsii.String(positionalConstraint),
+		SearchString:         jsii.String(byteMatch.SearchString),
+		TextTransformations:  textTransformations(byteMatch.TextTransformations),
+	}
+}
+
+// fieldToMatch converts a MatchField into the CloudFormation field to match
+func fieldToMatch(field MatchField, name string) *awswafv2.CfnWebACL_FieldToMatchProperty {
+	switch field {
+	case MatchFieldURIPath:
+		return &awswafv2.CfnWebACL_FieldToMatchProperty{UriPath: map[string]interface{}{}}
+	case MatchFieldQueryString:
+		return &awswafv2.CfnWebACL_FieldToMatchProperty{QueryString: map[string]interface{}{}}
+	case MatchFieldMethod:
+		return &awswafv2.CfnWebACL_FieldToMatchProperty{Method: map[string]interface{}{}}
+	case MatchFieldBody:
+		return &awswafv2.CfnWebACL_FieldToMatchProperty{
+			Body: &awswafv2.CfnWebACL_BodyProperty{
+				OversizeHandling: jsii.String("CONTINUE"),
+			},
+		}
+	case MatchFieldHeader:
+		if name == "" {
+			panic("Custom WAF: HEADER match requires FieldName")
+		}
+		return &awswafv2.CfnWebACL_FieldToMatchProperty{
+			SingleHeader: map[string]interface{}{"Name": strings.ToLower(name)},
+		}
+	case MatchFieldSingleQueryArgument:
+		if name == "" {
+			panic("Custom WAF: SINGLE_QUERY_ARGUMENT match requires FieldName")
+		}
+		return &awswafv2.CfnWebACL_FieldToMatchProperty{
+			SingleQueryArgument: map[string]interface{}{"Name": strings.ToLower(name)},
+		}
+	default:
+		panic(fmt.Sprintf("Custom WAF: unsupported match field: %s", field))
+	}
+}
+
+// textTransformations converts transformation types into prioritized transformations (default NONE)
+func textTransformations(types []string) *[]*awswafv2.CfnWebACL_TextTransformationProperty {
+	if len(types) == 0 {
+		types = []string{"NONE"}
+	}
+
+	transformations := make([]*awswafv2.CfnWebACL_TextTransformationProperty, len(types))
+	for i, transformationType := range types {
+		transformations[i] = &awswafv2.CfnWebACL_TextTransformationProperty{
+			Priority: jsii.Number(i),
+			Type:     jsii.String(transformationType),
+		}
+	}
+	return &transformations
+}
+
+// validateCustomRules fails synthesis on incomplete or ambiguous rule declarations
+func validateCustomRules(rules []CustomRule) {
+	if len(rules) == 0 {
+		panic("Custom WAF: CustomRules requires at least one rule")
+	}
+
+	names := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if rule.Name == "" {
+			panic("Custom WAF: every rule requires a Name")
+		}
+		if names[rule.Name] {
+			panic(fmt.Sprintf("Custom WAF: duplicate rule name %s", rule.Name))
+		}
+		names[rule.Name] = true
+
+		statements := 0
+		for _, set := range []bool{
+			rule.ManagedRuleGroup != nil,
+			rule.RateBased != nil,
+			rule.Geo != nil,
+			rule.IPSet != nil,
+			rule.ByteMatch != nil,
+			rule.RegexMatch != nil,
+			rule.LabelMatch != nil,
+		} {
+			if set {
+				statements++
+			}
+		}
+		if statements != 1 {
+			panic(fmt.Sprintf("Custom WAF: rule %s must define exactly one statement, got %d", rule.Name, statements))
+		}
+
+		if (rule.ResponseStatusCode != 0 || rule.ResponseBodyKey != "") && rule.Action != "" && rule.Action != RuleActionBlock {
+			panic(fmt.Sprintf("Custom WAF: rule %s sets a block response but its action is %s", rule.Name, rule.Action))
+		}
+		if rule.ResponseStatusCode != 0 && (rule.ResponseStatusCode < 200 || rule.ResponseStatusCode > 599) {
+			panic(fmt.Sprintf("Custom WAF: rule %s ResponseStatusCode must be between 200 and 599", rule.Name))
+		}
+
+		switch {
+		case rule.ManagedRuleGroup != nil:
+			if rule.ManagedRuleGroup.Name == "" {
+				panic(fmt.Sprintf("Custom WAF: rule %s requires ManagedRuleGroup.Name", rule.Name))
+			}
+			if rule.Action != "" && rule.Action != RuleActionCount {
+				panic(fmt.Sprintf("Custom WAF: managed rule group %s only accepts Action COUNT (or empty)", rule.Name))
+			}
+			if rule.Negate || rule.ResponseStatusCode != 0 || rule.ResponseBodyKey != "" {
+				panic(fmt.Sprintf("Custom WAF: managed rule group %s cannot be negated or set a block response", rule.Name))
+			}
+		case rule.RateBased != nil:
+			if rule.RateBased.Limit < 10 {
+				panic(fmt.Sprintf("Custom WAF: rate-based rule %s requires Limit >= 10", rule.Name))
+			}
+			switch rule.RateBased.EvaluationWindowSec {
+			case 0, 60, 120, 300, 600:
+			default:
+				panic(fmt.Sprintf("Custom WAF: rate-based rule %s EvaluationWindowSec must be 60, 120, 300 or 600", rule.Name))
+			}
+			if rule.Negate {
+				panic(fmt.Sprintf("Custom WAF: rate-based rule %s cannot be negated", rule.Name))
+			}
+		case rule.Geo != nil:
+			if len(rule.Geo.CountryCodes) == 0 {
+				panic(fmt.Sprintf("Custom WAF: geo rule %s requires CountryCodes", rule.Name))
+			}
+		case rule.IPSet != nil:
+			if (rule.IPSet.Arn == "") == (len(rule.IPSet.Addresses) == 0) {
+				panic(fmt.Sprintf("Custom WAF: IP set rule %s requires either Addresses or Arn", rule.Name))
+			}
+		case rule.LabelMatch != nil:
+			if rule.LabelMatch.Key == "" {
+				panic(fmt.Sprintf("Custom WAF: label match rule %s requires Key", rule.Name))
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package waf
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awswafv2"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// ResponseContentType defines the content type of a custom response body
+type ResponseContentType string
+
+const (
+	// ResponseContentTypeText returns the body as text/plain
+	ResponseContentTypeText ResponseContentType = "TEXT_PLAIN"
+
+	// ResponseContentTypeHTML returns the body as text/html (e.g. a branded error page)
+	ResponseContentTypeHTML ResponseContentType = "TEXT_HTML"
+
+	// ResponseContentTypeJSON returns the body as application/json (APIs)
+	ResponseContentTypeJSON ResponseContentType = "APPLICATION_JSON"
+)
+
+// CustomResponseBody is a named body that block actions reference by key
+// (WAFFactoryProps.CustomResponseBodies). Maximum 4KB per body
+type CustomResponseBody struct {
+	ContentType ResponseContentType
+	Content     string
+}
+
+// Immunity time limits accepted by WAF for CAPTCHA and Challenge (seconds)
+const (
+	minImmunityTime = 60
+	maxImmunityTime = 259200 // 3 days
+)
+
+var responseBodyKeyPattern = regexp.MustCompile(`^[\w\-]+$`)
+
+// addPropsCustomResponseBodies registers the named bodies declared in props
+func (b *ruleBuilder) addPropsCustomResponseBodies() {
+	for key, body := range b.props.CustomResponseBodies {
+		if !responseBodyKeyPattern.MatchString(key) {
+			panic(fmt.Sprintf("CustomResponseBodies: invalid key %q (letters, digits, _ and - only)", key))
+		}
+		if len(body.Content) == 0 || len(body.Content) > 4096 {
+			panic(fmt.Sprintf("CustomResponseBodies: %s content must be between 1 and 4096 bytes", key))
+		}
+		b.addCustomResponseBody(key, string(responseContentType(body.ContentType)), body.Content)
+	}
+}
+
+// blockWithResponse blocks with a status code and, optionally, a named
+// response body. statusCode 0 means 403 (or 429 for rate-based rules, set by the caller)
+func (b *ruleBuilder) blockWithResponse(statusCode int64, bodyKey string) *awswafv2.CfnWebACL_RuleActionProperty {
+	if statusCode == 0 {
+		statusCode = 403
+	}
+
+	action := blockWithStatus(float64(statusCode))
+
+	if bodyKey != "" {
+		if _, ok := b.customResponseBodies[bodyKey]; !ok {
+			panic(fmt.Sprintf("Response body key %s is not defined in CustomResponseBodies", bodyKey))
+		}
+		action.Block.CustomResponse.CustomResponseBodyKey = jsii.String(bodyKey)
+	}
+
+	return action
+}
+
+// captchaConfig returns the CAPTCHA immunity time configuration (nil keeps the WAF default of 300s)
+func (b *ruleBuilder) captchaConfig() *awswafv2.CfnWebACL_CaptchaConfigProperty {
+	immunityTime := b.immunityTime("CaptchaImmunityTime", b.props.CaptchaImmunityTime)
+	if immunityTime == nil {
+		return nil
+	}
+
+	return &awswafv2.CfnWebACL_CaptchaConfigProperty{
+		ImmunityTimeProperty: &awswafv2.CfnWebACL_ImmunityTimePropertyProperty{
+			ImmunityTime: immunityTime,
+		},
+	}
+}
+
+// challengeConfig returns the Challenge immunity time configuration (nil keeps the WAF default of 300s)
+func (b *ruleBuilder) challengeConfig() *awswafv2.CfnWebACL_ChallengeConfigProperty {
+	immunityTime := b.immunityTime("ChallengeImmunityTime", b.props.ChallengeImmunityTime)
+	if immunityTime == nil {
+		return nil
+	}
+
+	return &awswafv2.CfnWebACL_ChallengeConfigProperty{
+		ImmunityTimeProperty: &awswafv2.CfnWebACL_ImmunityTimePropertyProperty{
+			ImmunityTime: immunityTime,
+		},
+	}
+}
+
+// immunityTime validates the configured immunity time, falling back to the
+// strategy default (defaultImmunityTime, 0 = not set)
+func (b *ruleBuilder) immunityTime(option string, value *int64) *float64 {
+	seconds := b.defaultImmunityTime
+	if value != nil {
+		seconds = *value
+	}
+
+	if seconds == 0 {
+		return nil
+	}
+
+	if seconds < minImmunityTime || seconds > maxImmunityTime {
+		panic(fmt.Sprintf("%s must be between %d and %d seconds, got %d", option, minImmunityTime, maxImmunityTime, seconds))
+	}
+
+	return jsii.Number(seconds)
+}
+
+// responseContentType validates the content type (default TEXT_PLAIN)
+func responseContentType(contentType ResponseContentType) ResponseContentType {
+	switch contentType {
+	case "":
+		return ResponseContentTypeText
+	case ResponseContentTypeText, ResponseContentTypeHTML, ResponseContentTypeJSON:
+		return contentType
+	default:
+		panic(fmt.Sprintf("Unsupported response content type: %s (use TEXT_PLAIN, TEXT_HTML or APPLICATION_JSON)", contentType))
+	}
+}
@@ -20,9 +20,11 @@ const (
 	// ProfileTypeBotControl creates WAF with advanced bot detection and mitigation
 	ProfileTypeBotControl WAFProfileType = "BOT_CONTROL"
 
-	// TODO: Add more profile types as we implement them
-	// ProfileTypeWordPress     WAFProfileType = "WORDPRESS"
-	// ProfileTypeCustom        WAFProfileType = "CUSTOM"
+	// ProfileTypeWordPress creates WAF optimized for WordPress sites (wp-login/xmlrpc brute force, PHP exploits)
+	ProfileTypeWordPress WAFProfileType = "WORDPRESS"
+
+	// ProfileTypeCustom creates WAF from a declarative rule list (WAFFactoryProps.CustomRules)
+	ProfileTypeCustom WAFProfileType = "CUSTOM"
 )
 
 // WAFScope defines whether the WAF is for CloudFront (global) or regional resources
@@ -50,6 +52,11 @@ type WAFFactoryProps struct {
 	// Optional: Rate limiting (requests per 5 minutes per IP)
 	RateLimitRequests *int64
 
+	// Optional: Additional rate limits scoped to a path prefix, method or header,
+	// with their own aggregation key and 429 body. Evaluated in order, right
+	// after the profile's global rate limit
+	RateLimits []ScopedRateLimit
+
 	// Optional: Countries to block (ISO 3166-1 alpha-2 codes)
 	GeoBlockCountries []string
 
@@ -62,13 +69,70 @@ type WAFFactoryProps struct {
 	// Optional: IP addresses to always allow (whitelist)
 	AllowedIPs []string
 
+	// Optional: Block the remote IPs of GuardDuty findings for a limited time
+	// (EventBridge rule + Go Lambda updating dedicated IP sets)
+	GuardDutyBlocklist *GuardDutyBlocklistOptions
+
+	// Optional (WordPress): IP addresses allowed to reach /wp-admin (CIDR notation)
+	// Defaults to AllowedIPs. If both are empty /wp-admin is not restricted
+	AdminAllowedIPs []string
+
+	// Optional (WordPress): Rate limit for wp-login.php and xmlrpc.php
+	// (requests per 5 minutes per IP, default 100)
+	LoginRateLimitRequests *int64
+
+	// Optional: Named response bodies (HTML, JSON or text) referenced by block actions
+	// (RateLimitResponseBodyKey, ScopedRateLimit.ResponseBodyKey, CustomRule.ResponseBodyKey)
+	CustomResponseBodies map[string]CustomResponseBody
+
+	// Optional: Body returned by the profile rate limit rules (key of CustomResponseBodies)
+	RateLimitResponseBodyKey string
+
+	// Optional: Seconds a solved CAPTCHA stays valid (60-259200, WAF default 300)
+	CaptchaImmunityTime *int64
+
+	// Optional: Seconds a passed Challenge stays valid (60-259200, WAF default 300)
+	ChallengeImmunityTime *int64
+
+	// Optional (Bot Control): Action for suspicious-traffic signals
+	// (automated browsers, bot data centers, non-browser user agents).
+	// BLOCK (default), CHALLENGE, CAPTCHA or COUNT
+	BotControlAction RuleAction
+
+	// Optional: Account Takeover Prevention on the login endpoint (AWSManagedRulesATPRuleSet)
+	AccountTakeoverPrevention *AccountTakeoverPreventionOptions
+
+	// Optional: Account Creation Fraud Prevention on the sign-up flow (AWSManagedRulesACFPRuleSet)
+	AccountCreationFraudPrevention *AccountCreationFraudPreventionOptions
+
+	// Optional: Deploy every managed rule group in count mode (OverrideAction count).
+	// Use it to watch metrics and logs in production before enforcing
+	RolloutMode bool
+
+	// Optional: Pinned managed rule group versions, by group name
+	// (e.g. "AWSManagedRulesCommonRuleSet": "Version_1.10"). Default: the vendor's default version
+	ManagedRuleGroupVersions map[string]string
+
+	// Optional: Action overrides for rules inside managed rule groups or for the Web ACL's own rules
+	RuleActionOverrides []RuleActionOverride
+
+	// Required (Custom): Rules evaluated in the declared order (priorities are assigned automatically)
+	CustomRules []CustomRule
+
+	// Optional (Custom): Web ACL default action, ALLOW (default) or BLOCK
+	DefaultAction RuleAction
+
+	// Optional: Request logging to S3, CloudWatch Logs or Firehose
+	// (by default only BLOCK and COUNT requests, with Authorization and Cookie redacted)
+	Logging *LoggingOptions
+
 	// Optional: Enable request body inspection (increases costs)
 	InspectRequestBody *bool
 
-	// Optional: Enable CloudWatch metrics
+	// Optional: Enable CloudWatch metrics (default true)
 	EnableMetrics *bool
 
-	// Optional: Enable sampled request logging
+	// Optional: Enable sampled request logging (default true)
 	EnableSampledRequests *bool
 }
 
@@ -99,16 +163,28 @@ func NewWebApplicationFirewallFactory(scope constructs.Construct, id string, pro
 	case ProfileTypeBotControl:
 		strategy = &WAFBotControlStrategy{}
 
-	// TODO: Implement additional strategies
-	// case ProfileTypeWordPress:
-	//     strategy = &WAFWordPressStrategy{}
-	// case ProfileTypeCustom:
-	//     strategy = &WAFCustomStrategy{}
+	case ProfileTypeWordPress:
+		strategy = &WAFWordPressStrategy{}
+
+	case ProfileTypeCustom:
+		strategy = &WAFCustomStrategy{}
 
 	default:
 		panic(fmt.Sprintf("Unsupported WAF ProfileType: %s", props.ProfileType))
 	}
 
+	// CLOUDFRONT Web ACLs only exist in us-east-1
+	if props.Scope != ScopeRegional {
+		validateCloudFrontRegion(scope, id)
+	}
+
 	// Delegate Web ACL creation to selected strategy
-	return strategy.Build(scope, id, props)
+	webACL := strategy.Build(scope, id, props)
+
+	// Logging is common to every profile
+	if props.Logging != nil {
+		newLoggingConfiguration(scope, id, webACL, props.Logging)
+	}
+
+	return webACL
 }
@@ -0,0 +1,274 @@
+package waf
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awswafv2"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// AccountTakeoverPreventionOptions configures AWSManagedRulesATPRuleSet for the login endpoint.
+// ATP inspects login attempts for stolen credentials and credential stuffing.
+//
+// Pricing: $10/month + $1 per 1,000 login attempts analyzed. Integrating the
+// WAF client application SDK (token) on the login page is strongly recommended
+type AccountTakeoverPreventionOptions struct {
+	// Required: Path of the login endpoint that receives the credentials (e.g. "/api/login")
+	LoginPath string
+
+	// Optional: "JSON" (default) or "FORM_ENCODED"
+	PayloadType string
+
+	// Optional: Username field. JSON pointer for JSON payloads (default "/username"),
+	// form field name for FORM_ENCODED (default "username")
+	UsernameField string
+
+	// Optional: Password field (default "/password" or "password")
+	PasswordField string
+
+	// Optional: Treat LoginPath as a regular expression
+	EnableRegexInPath bool
+
+	// Optional: How to tell successful from failed logins (CLOUDFRONT scope only)
+	ResponseInspection *ResponseInspection
+}
+
+// AccountCreationFraudPreventionOptions configures AWSManagedRulesACFPRuleSet for the sign-up flow.
+// ACFP detects fake account creation (bots, disposable emails, reused credentials).
+//
+// Pricing: $10/month + $1 per 1,000 account creation attempts analyzed
+type AccountCreationFraudPreventionOptions struct {
+	// Required: Path of the page that renders the sign-up form (e.g. "/signup")
+	RegistrationPagePath string
+
+	// Required: Path of the endpoint that creates the account (e.g. "/api/signup")
+	CreationPath string
+
+	// Optional: "JSON" (default) or "FORM_ENCODED"
+	PayloadType string
+
+	// Optional: Username field (default "/username" or "username")
+	UsernameField string
+
+	// Optional: Password field (default "/password" or "password")
+	PasswordField string
+
+	// Optional: Email field (e.g. "/email")
+	EmailField string
+
+	// Optional: Phone number fields (e.g. "/phone")
+	PhoneNumberFields []string
+
+	// Optional: Address fields (e.g. "/address/street", "/address/city")
+	AddressFields []string
+
+	// Optional: Treat the paths as regular expressions
+	EnableRegexInPath bool
+
+	// Optional: How to tell successful from failed sign-ups (CLOUDFRONT scope only)
+	ResponseInspection *ResponseInspection
+}
+
+// ResponseInspection tells ATP/ACFP whether the origin accepted the request.
+// Set exactly one of StatusCode, Header, BodyContains or JSON.
+// AWS WAF only inspects responses for CloudFront distributions
+type ResponseInspection struct {
+	StatusCode   *StatusCodeInspection
+	Header       *HeaderInspection
+	BodyContains *BodyContainsInspection
+	JSON         *JSONInspection
+}
+
+// StatusCodeInspection classifies responses by HTTP status code (e.g. 200 / 401)
+type StatusCodeInspection struct {
+	SuccessCodes []int64
+	FailureCodes []int64
+}
+
+// HeaderInspection classifies responses by the value of a response header
+type HeaderInspection struct {
+	Name          string
+	SuccessValues []string
+	FailureValues []string
+}
+
+// BodyContainsInspection classifies responses by strings in the first 65KB of the body
+type BodyContainsInspection struct {
+	SuccessStrings []string
+	FailureStrings []string
+}
+
+// JSONInspection classifies responses by a JSON field of the body (e.g. "/login/status")
+type JSONInspection struct {
+	Identifier    string
+	SuccessValues []string
+	FailureValues []string
+}
+
+// addFraudPreventionRuleGroups appends ATP and ACFP when configured. They go last so
+// requests already blocked by cheaper rules are not billed as analyzed attempts
+func (b *ruleBuilder) addFraudPreventionRuleGroups() {
+	if atp := b.props.AccountTakeoverPrevention; atp != nil {
+		if atp.LoginPath == "" {
+			panic("AccountTakeoverPrevention requires LoginPath")
+		}
+
+		payloadType := fraudPreventionPayloadType(atp.PayloadType)
+
+		statement := awsManagedRuleGroup("AWSManagedRulesATPRuleSet")
+		statement.ManagedRuleGroupConfigs = &[]*awswafv2.CfnWebACL_ManagedRuleGroupConfigProperty{
+			{
+				AwsManagedRulesAtpRuleSet: &awswafv2.CfnWebACL_AWSManagedRulesATPRuleSetProperty{
+					LoginPath:         jsii.String(atp.LoginPath),
+					EnableRegexInPath: jsii.Bool(atp.EnableRegexInPath),
+					RequestInspection: &awswafv2.CfnWebACL_RequestInspectionProperty{
+						PayloadType:   jsii.String(payloadType),
+						UsernameField: fieldIdentifier(atp.UsernameField, payloadType, "username"),
+						PasswordField: fieldIdentifier(atp.PasswordField, payloadType, "password"),
+					},
+					ResponseInspection: b.responseInspection("AccountTakeoverPrevention", atp.ResponseInspection),
+				},
+			},
+		}
+		b.addManagedRuleGroup("AWSManagedRulesATPRuleSet", statement, false)
+	}
+
+	if acfp := b.props.AccountCreationFraudPrevention; acfp != nil {
+		if acfp.RegistrationPagePath == "" || acfp.CreationPath == "" {
+			panic("AccountCreationFraudPrevention requires RegistrationPagePath and CreationPath")
+		}
+
+		payloadType := fraudPreventionPayloadType(acfp.PayloadType)
+
+		requestInspection := &awswafv2.CfnWebACL_RequestInspectionACFPProperty{
+			PayloadType:   jsii.String(payloadType),
+			UsernameField: fieldIdentifier(acfp.UsernameField, payloadType, "username"),
+			PasswordField: fieldIdentifier(acfp.PasswordField, payloadType, "password"),
+		}
+		if acfp.EmailField != "" {
+			requestInspection.EmailField = fieldIdentifier(acfp.EmailField, payloadType, "")
+		}
+		if len(acfp.PhoneNumberFields) > 0 {
+			requestInspection.PhoneNumberFields = fieldIdentifiers(acfp.PhoneNumberFields)
+		}
+		if len(acfp.AddressFields) > 0 {
+			requestInspection.AddressFields = fieldIdentifiers(acfp.AddressFields)
+		}
+
+		statement := awsManagedRuleGroup("AWSManagedRulesACFPRuleSet")
+		statement.ManagedRuleGroupConfigs = &[]*awswafv2.CfnWebACL_ManagedRuleGroupConfigProperty{
+			{
+				AwsManagedRulesAcfpRuleSet: &awswafv2.CfnWebACL_AWSManagedRulesACFPRuleSetProperty{
+					RegistrationPagePath: jsii.String(acfp.RegistrationPagePath),
+					CreationPath:         jsii.String(acfp.CreationPath),
+					EnableRegexInPath:    jsii.Bool(acfp.EnableRegexInPath),
+					RequestInspection:    requestInspection,
+					ResponseInspection:   b.responseInspection("AccountCreationFraudPrevention", acfp.ResponseInspection),
+				},
+			},
+		}
+		b.addManagedRuleGroup("AWSManagedRulesACFPRuleSet", statement, false)
+	}
+}
+
+// responseInspection converts the response inspection options. WAF only
+// inspects origin responses on CloudFront, so REGIONAL scope fails synthesis
+func (b *ruleBuilder) responseInspection(option string, inspection *ResponseInspection) *awswafv2.CfnWebACL_ResponseInspectionProperty {
+	if inspection == nil {
+		return nil
+	}
+
+	if b.wafScope != "CLOUDFRONT" {
+		panic(fmt.Sprintf("%s.ResponseInspection is only supported with ScopeCloudFront", option))
+	}
+
+	inspections := 0
+	result := &awswafv2.CfnWebACL_ResponseInspectionProperty{}
+
+	if inspection.StatusCode != nil {
+		inspections++
+		result.StatusCode = &awswafv2.CfnWebACL_ResponseInspectionStatusCodeProperty{
+			SuccessCodes: numbers(inspection.StatusCode.SuccessCodes),
+			FailureCodes: numbers(inspection.StatusCode.FailureCodes),
+		}
+	}
+
+	if inspection.Header != nil {
+		inspections++
+		result.Header = &awswafv2.CfnWebACL_ResponseInspectionHeaderProperty{
+			Name:          jsii.String(inspection.Header.Name),
+			SuccessValues: jsii.Strings(inspection.Header.SuccessValues...),
+			FailureValues: jsii.Strings(inspection.Header.FailureValues...),
+		}
+	}
+
+	if inspection.BodyContains != nil {
+		inspections++
+		result.BodyContains = &awswafv2.CfnWebACL_ResponseInspectionBodyContainsProperty{
+			SuccessStrings: jsii.Strings(inspection.BodyContains.SuccessStrings...),
+			FailureStrings: jsii.Strings(inspection.BodyContains.FailureStrings...),
+		}
+	}
+
+	if inspection.JSON != nil {
+		inspections++
+		result.Json = &awswafv2.CfnWebACL_ResponseInspectionJsonProperty{
+			Identifier:    jsii.String(inspection.JSON.Identifier),
+			SuccessValues: jsii.Strings(inspection.JSON.SuccessValues...),
+			FailureValues: jsii.Strings(inspection.JSON.FailureValues...),
+		}
+	}
+
+	if inspections != 1 {
+		panic(fmt.Sprintf("%s.ResponseInspection requires exactly one of StatusCode, Header, BodyContains or JSON", option))
+	}
+
+	return result
+}
+
+// fraudPreventionPayloadType validates the payload type (default JSON)
+func fraudPreventionPayloadType(payloadType string) string {
+	switch payloadType {
+	case "":
+		return "JSON"
+	case "JSON", "FORM_ENCODED":
+		return payloadType
+	default:
+		panic(fmt.Sprintf("Unsupported fraud prevention PayloadType: %s (use JSON or FORM_ENCODED)", payloadType))
+	}
+}
+
+// fieldIdentifier returns the request field, defaulting to a JSON pointer
+// ("/name") or a form field ("name") depending on the payload type
+func fieldIdentifier(identifier, payloadType, defaultName string) *awswafv2.CfnWebACL_FieldIdentifierProperty {
+	if identifier == "" {
+		identifier = defaultName
+		if payloadType == "JSON" {
+			identifier = "/" + defaultName
+		}
+	}
+
+	return &awswafv2.CfnWebACL_FieldIdentifierProperty{
+		Identifier: jsii.String(identifier),
+	}
+}
+
+// fieldIdentifiers converts a list of field names
+func fieldIdentifiers(identifiers []string) *[]interface{} {
+	fields := make([]interface{}, len(identifiers))
+	for i, identifier := range identifiers {
+		fields[i] = &awswafv2.CfnWebACL_FieldIdentifierProperty{
+			Identifier: jsii.String(identifier),
+		}
+	}
+	return &fields
+}
+
+// numbers converts integers into jsii numbers
+func numbers(values []int64) *[]*float64 {
+	result := make([]*float64, len(values))
+	for i, value := range values {
+		result[i] = jsii.Number(value)
+	}
+	return &result
+}
@@ -0,0 +1,193 @@
+package waf
+
+import (
+	"fmt"
+
+	golambda "cdk-library/constructs/Lambda"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsdynamodb"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsevents"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awseventstargets"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsiam"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awswafv2"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// defaultBlocklistCodePath is the blocklist Lambda directory, relative to the project root
+// (build it first with constructs/WAF/build-lambda.sh)
+const defaultBlocklistCodePath = "constructs/WAF/lambda/guardduty-blocklist"
+
+// maxIPSetAddresses is the WAF limit of addresses per IP set
+const maxIPSetAddresses = 10000
+
+// GuardDutyBlocklistOptions configures the dynamic blocklist fed by GuardDuty findings.
+//
+// An EventBridge rule sends findings at or above MinimumSeverity to a Go Lambda, which
+// blocks the remote IPs of the finding for BlockDuration. Blocks are stored in a
+// DynamoDB table; a scheduled run every 5 minutes removes the expired ones.
+//
+// GuardDuty findings are regional: only findings of the stack region are processed
+// (us-east-1 for CLOUDFRONT Web ACLs)
+type GuardDutyBlocklistOptions struct {
+	// Optional: Minimum finding severity that blocks an IP (1-10, default 7 = HIGH)
+	MinimumSeverity float64
+
+	// Optional: How long an IP stays blocked after its last finding (default 24 hours, minimum 5 minutes)
+	BlockDuration awscdk.Duration
+
+	// Optional: Finding type prefixes to act on (e.g. "Recon:EC2/PortProbeUnprotectedPort",
+	// "UnauthorizedAccess:"). Default: every finding type
+	FindingTypes []string
+
+	// Optional: Maximum IPs per IP set, applied to IPv4 and IPv6 separately; newest blocks win
+	// (default and maximum 10000)
+	MaxBlockedIPs int64
+
+	// Optional: Directory with the compiled Lambda, relative to the project root
+	// (default "constructs/WAF/lambda/guardduty-blocklist")
+	CodePath string
+}
+
+// addGuardDutyBlocklist creates the IPv4/IPv6 IP sets managed by the blocklist
+// Lambda and appends the rule that blocks them
+func (b *ruleBuilder) addGuardDutyBlocklist() {
+	options := b.props.GuardDutyBlocklist
+	if options == nil {
+		return
+	}
+
+	minimumSeverity := options.MinimumSeverity
+	if minimumSeverity == 0 {
+		minimumSeverity = 7
+	}
+	if minimumSeverity < 1 || minimumSeverity > 10 {
+		panic(fmt.Sprintf("GuardDutyBlocklist.MinimumSeverity must be between 1 and 10, got %.1f", minimumSeverity))
+	}
+
+	blockDuration := options.BlockDuration
+	if blockDuration == nil {
+		blockDuration = awscdk.Duration_Hours(jsii.Number(24))
+	}
+	blockSeconds := *blockDuration.ToSeconds(nil)
+	if blockSeconds < 300 {
+		panic(fmt.Sprintf("GuardDutyBlocklist.BlockDuration must be at least 5 minutes, got %.0f seconds", blockSeconds))
+	}
+
+	maxBlockedIPs := options.MaxBlockedIPs
+	if maxBlockedIPs == 0 {
+		maxBlockedIPs = maxIPSetAddresses
+	}
+	if maxBlockedIPs < 1 || maxBlockedIPs > maxIPSetAddresses {
+		panic(fmt.Sprintf("GuardDutyBlocklist.MaxBlockedIPs must be between 1 and %d, got %d", maxIPSetAddresses, maxBlockedIPs))
+	}
+
+	codePath := options.CodePath
+	if codePath == "" {
+		codePath = defaultBlocklistCodePath
+	}
+
+	// IP sets start empty: the Lambda owns their addresses
+	ipv4Set := b.newIPSet("GuardDutyBlocklistIPv4Set", "-GuardDutyBlocklist-IPv4", "IPv4 addresses flagged by GuardDuty (managed by Lambda)", "IPV4", nil)
+	ipv6Set := b.newIPSet("GuardDutyBlocklistIPv6Set", "-GuardDutyBlocklist-IPv6", "IPv6 addresses flagged by GuardDuty (managed by Lambda)", "IPV6", nil)
+
+	b.addRule("GuardDutyBlocklistRule",
+		&awswafv2.CfnWebACL_StatementProperty{
+			OrStatement: &awswafv2.CfnWebACL_OrStatementProperty{
+				Statements: &[]interface{}{
+					&awswafv2.CfnWebACL_StatementProperty{
+						IpSetReferenceStatement: &awswafv2.CfnWebACL_IPSetReferenceStatementProperty{
+							Arn: ipv4Set.AttrArn(),
+						},
+					},
+					&awswafv2.CfnWebACL_StatementProperty{
+						IpSetReferenceStatement: &awswafv2.CfnWebACL_IPSetReferenceStatementProperty{
+							Arn: ipv6Set.AttrArn(),
+						},
+					},
+				},
+			},
+		},
+		ruleAction(RuleActionBlock),
+	)
+
+	// Blocked IPs with their expiry (the table is the source of truth of the IP sets)
+	table := awsdynamodb.NewTable(b.scope, jsii.String(b.id+"GuardDutyBlocklistTable"), &awsdynamodb.TableProps{
+		PartitionKey: &awsdynamodb.Attribute{
+			Name: jsii.String("ip"),
+			Type: awsdynamodb.AttributeType_STRING,
+		},
+		BillingMode:         awsdynamodb.BillingMode_PAY_PER_REQUEST,
+		TimeToLiveAttribute: jsii.String("ttl"),
+		RemovalPolicy:       awscdk.RemovalPolicy_DESTROY,
+	})
+
+	// Reserved concurrency 1 serializes the IP set updates (fewer lock token conflicts)
+	function := golambda.NewGoLambda(b.scope, b.id+"GuardDutyBlocklistFunction", golambda.GoLambdaProps{
+		FunctionName:                 blocklistFunctionName(b.webACLName),
+		CodePath:                     codePath,
+		Handler:                      jsii.String("bootstrap"),
+		Description:                  jsii.String("Blocks IPs reported by GuardDuty in Web ACL " + b.webACLName),
+		MemorySize:                   jsii.Number(256),
+		Timeout:                      awscdk.Duration_Minutes(jsii.Number(1)),
+		ReservedConcurrentExecutions: jsii.Number(1),
+		Environment: &map[string]*string{
+			"TABLE_NAME":             table.TableName(),
+			"WAF_SCOPE":              jsii.String(b.wafScope),
+			"IPV4_SET_NAME":          ipv4Set.Name(),
+			"IPV4_SET_ID":            ipv4Set.AttrId(),
+			"IPV6_SET_NAME":          ipv6Set.Name(),
+			"IPV6_SET_ID":            ipv6Set.AttrId(),
+			"BLOCK_DURATION_SECONDS": jsii.String(fmt.Sprintf("%.0f", blockSeconds)),
+			"MAX_BLOCKED_IPS":        jsii.String(fmt.Sprintf("%d", maxBlockedIPs)),
+		},
+	})
+
+	table.GrantReadWriteData(function)
+	function.AddToRolePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Actions:   jsii.Strings("wafv2:GetIPSet", "wafv2:UpdateIPSet"),
+		Resources: jsii.Strings(*ipv4Set.AttrArn(), *ipv6Set.AttrArn()),
+	}))
+
+	// Findings at or above the minimum severity (optionally filtered by type)
+	detail := map[string]interface{}{
+		"severity": []interface{}{
+			map[string]interface{}{"numeric": []interface{}{">=", minimumSeverity}},
+		},
+	}
+	if len(options.FindingTypes) > 0 {
+		findingTypes := make([]interface{}, len(options.FindingTypes))
+		for i, findingType := range options.FindingTypes {
+			findingTypes[i] = map[string]interface{}{"prefix": findingType}
+		}
+		detail["type"] = findingTypes
+	}
+
+	findingsRule := awsevents.NewRule(b.scope, jsii.String(b.id+"GuardDutyBlocklistFindingsRule"), &awsevents.RuleProps{
+		Description: jsii.String(fmt.Sprintf("Blocks remote IPs of GuardDuty findings with severity >= %.1f in %s", minimumSeverity, b.webACLName)),
+		EventPattern: &awsevents.EventPattern{
+			Source:     jsii.Strings("aws.guardduty"),
+			DetailType: jsii.Strings("GuardDuty Finding"),
+			Detail:     &detail,
+		},
+	})
+	findingsRule.AddTarget(awseventstargets.NewLambdaFunction(function, &awseventstargets.LambdaFunctionProps{
+		RetryAttempts: jsii.Number(2),
+	}))
+
+	// Expired blocks are removed from the IP sets every 5 minutes
+	expiryRule := awsevents.NewRule(b.scope, jsii.String(b.id+"GuardDutyBlocklistExpiryRule"), &awsevents.RuleProps{
+		Description: jsii.String("Removes expired GuardDuty blocks from " + b.webACLName),
+		Schedule:    awsevents.Schedule_Rate(awscdk.Duration_Minutes(jsii.Number(5))),
+	})
+	expiryRule.AddTarget(awseventstargets.NewLambdaFunction(function, nil))
+}
+
+// blocklistFunctionName builds the Lambda name (max 64 characters)
+func blocklistFunctionName(webACLName string) string {
+	const suffix = "-guardduty-blocklist"
+	if len(webACLName) > 64-len(suffix) {
+		webACLName = webACLName[:64-len(suffix)]
+	}
+	return webACLName + suffix
+}
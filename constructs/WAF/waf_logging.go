@@ -0,0 +1,318 @@
+package waf
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsiam"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awskinesisfirehose"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awslogs"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awss3"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awswafv2"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// LoggingDestinationType defines where WAF writes its request logs
+type LoggingDestinationType string
+
+const (
+	// LoggingDestinationS3 writes logs directly to an "aws-waf-logs-" S3 bucket (cheapest, 5-minute batches)
+	LoggingDestinationS3 LoggingDestinationType = "S3"
+
+	// LoggingDestinationCloudWatchLogs writes logs to an "aws-waf-logs-" log group (queryable with Logs Insights)
+	LoggingDestinationCloudWatchLogs LoggingDestinationType = "CLOUDWATCH_LOGS"
+
+	// LoggingDestinationFirehose writes logs to an "aws-waf-logs-" Firehose stream delivering to S3
+	// (use it to transform logs or forward them to a SIEM)
+	LoggingDestinationFirehose LoggingDestinationType = "FIREHOSE"
+)
+
+// wafLogsPrefix is the name prefix WAF requires on every log destination
+const wafLogsPrefix = "aws-waf-logs-"
+
+// defaultRedactedHeaders are never written to the logs (credentials and sessions)
+var defaultRedactedHeaders = []string{"authorization", "cookie"}
+
+// LoggingOptions configures the Web ACL logging (CfnLoggingConfiguration)
+type LoggingOptions struct {
+	// Required: Destination created for the Web ACL
+	DestinationType LoggingDestinationType
+
+	// Optional: Days logs are kept (S3 lifecycle or log group retention, default 90)
+	RetentionDays int
+
+	// Optional: Removal policy of the destination (default RETAIN)
+	RemovalPolicy awscdk.RemovalPolicy
+
+	// Optional: Headers removed from the logs (default Authorization and Cookie)
+	RedactedHeaders []string
+
+	// Optional: Actions written to the logs (default BLOCK and COUNT). Other requests are dropped
+	LoggedActions []RuleAction
+
+	// Optional: Log every request, ignoring LoggedActions (increases costs)
+	LogAllRequests bool
+}
+
+// newLoggingConfiguration creates the log destination and attaches it to the Web ACL
+func newLoggingConfiguration(scope constructs.Construct, id string, webACL awswafv2.CfnWebACL, options *LoggingOptions) awswafv2.CfnLoggingConfiguration {
+	retentionDays := options.RetentionDays
+	if retentionDays <= 0 {
+		retentionDays = 90
+	}
+
+	removalPolicy := options.RemovalPolicy
+	if removalPolicy == "" {
+		removalPolicy = awscdk.RemovalPolicy_RETAIN
+	}
+
+	destinationName := wafLogsDestinationName(*webACL.Name())
+
+	var destinationArn *string
+	var destinationDependencies []constructs.IDependable
+	switch options.DestinationType {
+	case LoggingDestinationS3:
+		bucket := newWafLogBucket(scope, id+"LogBucket", destinationName, retentionDays, removalPolicy)
+		grantWafLogDelivery(scope, bucket)
+		destinationArn = bucket.BucketArn()
+
+	case LoggingDestinationCloudWatchLogs:
+		logGroup := awslogs.NewLogGroup(scope, jsii.String(id+"LogGroup"), &awslogs.LogGroupProps{
+			LogGroupName:  jsii.String(destinationName),
+			Retention:     logRetention(retentionDays),
+			RemovalPolicy: removalPolicy,
+		})
+		policy := grantWafLogGroupDelivery(scope, id+"LogGroupPolicy", destinationName, logGroup)
+		destinationDependencies = append(destinationDependencies, logGroup, policy)
+		// WAF expects the log group ARN without the ":*" suffix returned by LogGroupArn()
+		destinationArn = awscdk.Stack_Of(scope).FormatArn(&awscdk.ArnComponents{
+			Service:      jsii.String("logs"),
+			Resource:     jsii.String("log-group"),
+			ResourceName: jsii.String(destinationName),
+			ArnFormat:    awscdk.ArnFormat_COLON_RESOURCE_NAME,
+		})
+
+	case LoggingDestinationFirehose:
+		// The bucket behind the stream doesn't need the aws-waf-logs- prefix
+		bucket := newWafLogBucket(scope, id+"LogBucket", "", retentionDays, removalPolicy)
+		stream := awskinesisfirehose.NewDeliveryStream(scope, jsii.String(id+"LogDeliveryStream"), &awskinesisfirehose.DeliveryStreamProps{
+			DeliveryStreamName: jsii.String(destinationName),
+			Destination: awskinesisfirehose.NewS3Bucket(bucket, &awskinesisfirehose.S3BucketProps{
+				DataOutputPrefix:  jsii.String("waf-logs/"),
+				ErrorOutputPrefix: jsii.String("waf-logs-errors/"),
+			}),
+		})
+		destinationArn = stream.DeliveryStreamArn()
+
+	default:
+		panic(fmt.Sprintf("Unsupported WAF logging DestinationType: %s", options.DestinationType))
+	}
+
+	// Redacted headers
+	redactedHeaders := options.RedactedHeaders
+	if len(redactedHeaders) == 0 {
+		redactedHeaders = defaultRedactedHeaders
+	}
+
+	redactedFields := make([]interface{}, len(redactedHeaders))
+	for i, header := range redactedHeaders {
+		redactedFields[i] = &awswafv2.CfnLoggingConfiguration_FieldToMatchProperty{
+			SingleHeader: map[string]interface{}{"Name": strings.ToLower(header)},
+		}
+	}
+
+	loggingConfiguration := awswafv2.NewCfnLoggingConfiguration(scope, jsii.String(id+"LoggingConfiguration"), &awswafv2.CfnLoggingConfigurationProps{
+		ResourceArn:           webACL.AttrArn(),
+		LogDestinationConfigs: &[]*string{destinationArn},
+		RedactedFields:        &redactedFields,
+		LoggingFilter:         loggingFilter(options),
+	})
+
+	// The log group ARN is built from its name: make sure the group and its policy exist first
+	loggingConfiguration.Node().AddDependency(destinationDependencies...)
+
+	return loggingConfiguration
+}
+
+// loggingFilter keeps only the requests whose terminating action is in
+// LoggedActions (default BLOCK and COUNT). Returns nil with LogAllRequests
+func loggingFilter(options *LoggingOptions) *awswafv2.CfnLoggingConfiguration_LoggingFilterProperty {
+	if options.LogAllRequests {
+		return nil
+	}
+
+	actions := options.LoggedActions
+	if len(actions) == 0 {
+		actions = []RuleAction{RuleActionBlock, RuleActionCount}
+	}
+
+	conditions := make([]interface{}, len(actions))
+	for i, action := range actions {
+		switch action {
+		case RuleActionAllow, RuleActionBlock, RuleActionCount, RuleActionCaptcha, RuleActionChallenge:
+		default:
+			panic(fmt.Sprintf("Unsupported WAF logging action: %s", action))
+		}
+
+		conditions[i] = &awswafv2.CfnLoggingConfiguration_ConditionProperty{
+			ActionCondition: &awswafv2.CfnLoggingConfiguration_ActionConditionProperty{
+				Action: jsii.String(string(action)),
+			},
+		}
+	}
+
+	return &awswafv2.CfnLoggingConfiguration_LoggingFilterProperty{
+		DefaultBehavior: jsii.String("DROP"),
+		Filters: &[]interface{}{
+			&awswafv2.CfnLoggingConfiguration_FilterProperty{
+				Behavior:    jsii.String("KEEP"),
+				Requirement: jsii.String("MEETS_ANY"),
+				Conditions:  &conditions,
+			},
+		},
+	}
+}
+
+// newWafLogBucket creates an encrypted, private bucket with log expiration.
+// An empty bucketName lets CloudFormation generate it
+func newWafLogBucket(scope constructs.Construct, id, bucketName string, retentionDays int, removalPolicy awscdk.RemovalPolicy) awss3.Bucket {
+	props := &awss3.BucketProps{
+		BlockPublicAccess: awss3.BlockPublicAccess_BLOCK_ALL(),
+		Encryption:        awss3.BucketEncryption_S3_MANAGED,
+		EnforceSSL:        jsii.Bool(true),
+		RemovalPolicy:     removalPolicy,
+		AutoDeleteObjects: jsii.Bool(removalPolicy == awscdk.RemovalPolicy_DESTROY),
+		LifecycleRules: &[]*awss3.LifecycleRule{
+			{
+				Id:         jsii.String("ExpireWafLogs"),
+				Enabled:    jsii.Bool(true),
+				Expiration: awscdk.Duration_Days(jsii.Number(retentionDays)),
+			},
+		},
+	}
+
+	if bucketName != "" {
+		// Bucket names are global: account and region keep them unique
+		stack := awscdk.Stack_Of(scope)
+		props.BucketName = awscdk.Fn_Join(jsii.String("-"), &[]*string{
+			jsii.String(bucketName),
+			stack.Account(),
+			stack.Region(),
+		})
+	}
+
+	return awss3.NewBucket(scope, jsii.String(id), props)
+}
+
+// grantWafLogDelivery allows the log delivery service to write WAF logs to the bucket
+// (same policy WAF creates when logging is enabled from the console)
+func grantWafLogDelivery(scope constructs.Construct, bucket awss3.Bucket) {
+	account := awscdk.Stack_Of(scope).Account()
+	deliveryPrincipal := awsiam.NewServicePrincipal(jsii.String("delivery.logs.amazonaws.com"), nil)
+
+	bucket.AddToResourcePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Sid:        jsii.String("AWSLogDeliveryWrite"),
+		Principals: &[]awsiam.IPrincipal{deliveryPrincipal},
+		Actions:    jsii.Strings("s3:PutObject"),
+		Resources:  jsii.Strings(*bucket.ArnForObjects(jsii.String("AWSLogs/" + *account + "/*"))),
+		Conditions: &map[string]interface{}{
+			"StringEquals": map[string]interface{}{
+				"s3:x-amz-acl":      "bucket-owner-full-control",
+				"aws:SourceAccount": account,
+			},
+		},
+	}))
+
+	bucket.AddToResourcePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Sid:        jsii.String("AWSLogDeliveryAclCheck"),
+		Principals: &[]awsiam.IPrincipal{deliveryPrincipal},
+		Actions:    jsii.Strings("s3:GetBucketAcl"),
+		Resources:  jsii.Strings(*bucket.BucketArn()),
+		Conditions: &map[string]interface{}{
+			"StringEquals": map[string]interface{}{
+				"aws:SourceAccount": account,
+			},
+		},
+	}))
+}
+
+// grantWafLogGroupDelivery allows the log delivery service to write WAF logs to the
+// log group (same policy WAF creates when logging is enabled from the console, but
+// created up front instead of relying on WAF to add one, which fails once the account
+// reaches the limit of 10 CloudWatch Logs resource policies)
+func grantWafLogGroupDelivery(scope constructs.Construct, id, logGroupName string, logGroup awslogs.LogGroup) awslogs.ResourcePolicy {
+	stack := awscdk.Stack_Of(scope)
+
+	return awslogs.NewResourcePolicy(scope, jsii.String(id), &awslogs.ResourcePolicyProps{
+		ResourcePolicyName: jsii.String(logGroupName + "-delivery"),
+		PolicyStatements: &[]awsiam.PolicyStatement{
+			awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+				Sid:        jsii.String("AWSLogDeliveryWrite"),
+				Principals: &[]awsiam.IPrincipal{awsiam.NewServicePrincipal(jsii.String("delivery.logs.amazonaws.com"), nil)},
+				Actions:    jsii.Strings("logs:CreateLogStream", "logs:PutLogEvents"),
+				// LogGroupArn() ends with ":*", covering the log streams of the group
+				Resources: jsii.Strings(*logGroup.LogGroupArn()),
+				Conditions: &map[string]interface{}{
+					"StringEquals": map[string]interface{}{
+						"aws:SourceAccount": stack.Account(),
+					},
+					"ArnLike": map[string]interface{}{
+						"aws:SourceArn": stack.FormatArn(&awscdk.ArnComponents{
+							Service:   jsii.String("logs"),
+							Resource:  jsii.String("*"),
+							ArnFormat: awscdk.ArnFormat_NO_RESOURCE_NAME,
+						}),
+					},
+				},
+			}),
+		},
+	})
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// wafLogsDestinationName builds "aws-waf-logs-<web-acl-name>" in lowercase,
+// short enough to append account and region to bucket names (63 chars max)
+func wafLogsDestinationName(webACLName string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(webACLName), "-")
+	if len(name) > 20 {
+		name = name[:20]
+	}
+	return wafLogsPrefix + strings.Trim(name, "-")
+}
+
+// logRetention maps days to the closest CloudWatch Logs retention that keeps at least that many days
+func logRetention(days int) awslogs.RetentionDays {
+	retentions := []struct {
+		days      int
+		retention awslogs.RetentionDays
+	}{
+		{1, awslogs.RetentionDays_ONE_DAY},
+		{3, awslogs.RetentionDays_THREE_DAYS},
+		{5, awslogs.RetentionDays_FIVE_DAYS},
+		{7, awslogs.RetentionDays_ONE_WEEK},
+		{14, awslogs.RetentionDays_TWO_WEEKS},
+		{30, awslogs.RetentionDays_ONE_MONTH},
+		{60, awslogs.RetentionDays_TWO_MONTHS},
+		{90, awslogs.RetentionDays_THREE_MONTHS},
+		{120, awslogs.RetentionDays_FOUR_MONTHS},
+		{150, awslogs.RetentionDays_FIVE_MONTHS},
+		{180, awslogs.RetentionDays_SIX_MONTHS},
+		{365, awslogs.RetentionDays_ONE_YEAR},
+		{400, awslogs.RetentionDays_THIRTEEN_MONTHS},
+		{545, awslogs.RetentionDays_EIGHTEEN_MONTHS},
+		{731, awslogs.RetentionDays_TWO_YEARS},
+		{1827, awslogs.RetentionDays_FIVE_YEARS},
+		{3653, awslogs.RetentionDays_TEN_YEARS},
+	}
+
+	for _, r := range retentions {
+		if days <= r.days {
+			return r.retention
+		}
+	}
+	return awslogs.RetentionDays_INFINITE
+}
@@ -0,0 +1,333 @@
+package waf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awswafv2"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// RateLimitAggregateKey defines how requests are grouped when counting a rate limit
+type RateLimitAggregateKey string
+
+const (
+	// AggregateKeyIP counts per client IP (default)
+	AggregateKeyIP RateLimitAggregateKey = "IP"
+
+	// AggregateKeyForwardedIP counts per IP taken from a header (e.g. X-Forwarded-For
+	// behind a proxy or CDN that is not CloudFront)
+	AggregateKeyForwardedIP RateLimitAggregateKey = "FORWARDED_IP"
+
+	// AggregateKeyHeader counts per value of a header (e.g. an API key)
+	AggregateKeyHeader RateLimitAggregateKey = "HEADER"
+
+	// AggregateKeyCustomKeys counts per combination of CustomKeys (e.g. IP + URI path)
+	AggregateKeyCustomKeys RateLimitAggregateKey = "CUSTOM_KEYS"
+)
+
+// RateLimitKeyType defines one component of a custom aggregation key
+type RateLimitKeyType string
+
+const (
+	// RateLimitKeyIP is the client IP
+	RateLimitKeyIP RateLimitKeyType = "IP"
+
+	// RateLimitKeyForwardedIP is the IP in AggregateHeaderName (default X-Forwarded-For)
+	RateLimitKeyForwardedIP RateLimitKeyType = "FORWARDED_IP"
+
+	// RateLimitKeyHeader is the value of the header Name
+	RateLimitKeyHeader RateLimitKeyType = "HEADER"
+
+	// RateLimitKeyCookie is the value of the cookie Name
+	RateLimitKeyCookie RateLimitKeyType = "COOKIE"
+
+	// RateLimitKeyQueryArgument is the value of the query argument Name
+	RateLimitKeyQueryArgument RateLimitKeyType = "QUERY_ARGUMENT"
+
+	// RateLimitKeyQueryString is the whole query string
+	RateLimitKeyQueryString RateLimitKeyType = "QUERY_STRING"
+
+	// RateLimitKeyURIPath is the URI path
+	RateLimitKeyURIPath RateLimitKeyType = "URI_PATH"
+
+	// RateLimitKeyHTTPMethod is the HTTP method
+	RateLimitKeyHTTPMethod RateLimitKeyType = "HTTP_METHOD"
+
+	// RateLimitKeyLabelNamespace is the label namespace Name (e.g. "awswaf:managed:aws:bot-control:bot:")
+	RateLimitKeyLabelNamespace RateLimitKeyType = "LABEL_NAMESPACE"
+)
+
+// RateLimitCustomKey is one component of a CUSTOM_KEYS aggregation
+type RateLimitCustomKey struct {
+	Type RateLimitKeyType
+
+	// Header, cookie or query argument name, or label namespace
+	Name string
+}
+
+// HeaderMatch matches requests whose header equals a value
+type HeaderMatch struct {
+	Name  string
+	Value string
+}
+
+// ScopedRateLimit is a rate-based rule applied only to the requests that match
+// its scope (path prefix, method and/or header). An empty scope counts every request.
+//
+// Example: 100 req/5min per IP on /login with a JSON 429 body
+//
+//	waf.ScopedRateLimit{
+//	    Name:         "LoginRateLimit",
+//	    Limit:        100,
+//	    PathPrefix:   "/login",
+//	    ResponseBody: `{"error":"too_many_requests"}`,
+//	}
+type ScopedRateLimit struct {
+	// Required: Rule name (also used as CloudWatch metric name)
+	Name string
+
+	// Required: Maximum requests per aggregation key in the evaluation window (minimum 10)
+	Limit int64
+
+	// Optional: Evaluation window in seconds: 60, 120, 300 or 600 (default 300)
+	EvaluationWindowSec int64
+
+	// Optional scope (combined with AND)
+	PathPrefix string       // URI path prefix, case-insensitive (e.g. "/api/search")
+	Method     string       // HTTP method (e.g. "POST")
+	Header     *HeaderMatch // Header value (e.g. X-Api-Version: 2)
+
+	// Optional: Aggregation key (default IP)
+	AggregateKey RateLimitAggregateKey
+
+	// Optional: Header used by AggregateKeyHeader, or the forwarded IP header
+	// for AggregateKeyForwardedIP (default "X-Forwarded-For")
+	AggregateHeaderName string
+
+	// Required for AggregateKeyCustomKeys
+	CustomKeys []RateLimitCustomKey
+
+	// Optional: Body of the 429 response
+	ResponseBody string
+
+	// Optional: Content type of ResponseBody (default TEXT_PLAIN)
+	ResponseContentType ResponseContentType
+
+	// Optional: Key of a body in WAFFactoryProps.CustomResponseBodies (instead of ResponseBody)
+	ResponseBodyKey string
+}
+
+// addScopedRateLimits appends one rate-based rule per props.RateLimits, in order
+func (b *ruleBuilder) addScopedRateLimits() {
+	for _, rateLimit := range b.props.RateLimits {
+		validateScopedRateLimit(rateLimit)
+
+		// Inline bodies are registered under the rule name
+		bodyKey := rateLimit.ResponseBodyKey
+		if rateLimit.ResponseBody != "" {
+			bodyKey = rateLimit.Name
+			b.addCustomResponseBody(bodyKey, string(responseContentType(rateLimit.ResponseContentType)), rateLimit.ResponseBody)
+		}
+
+		action := b.blockWithResponse(429, bodyKey) // Too Many Requests
+
+		b.addRule(rateLimit.Name,
+			&awswafv2.CfnWebACL_StatementProperty{
+				RateBasedStatement: scopedRateBasedStatement(rateLimit),
+			},
+			action,
+		)
+	}
+}
+
+// scopedRateBasedStatement builds the rate-based statement with its aggregation key and scope-down
+func scopedRateBasedStatement(rateLimit ScopedRateLimit) *awswafv2.CfnWebACL_RateBasedStatementProperty {
+	statement := &awswafv2.CfnWebACL_RateBasedStatementProperty{
+		Limit:              jsii.Number(float64(rateLimit.Limit)),
+		ScopeDownStatement: rateLimitScopeDown(rateLimit),
+	}
+
+	if rateLimit.EvaluationWindowSec > 0 {
+		statement.EvaluationWindowSec = jsii.Number(float64(rateLimit.EvaluationWindowSec))
+	}
+
+	forwardedIPHeader := rateLimit.AggregateHeaderName
+	if forwardedIPHeader == "" {
+		forwardedIPHeader = "X-Forwarded-For"
+	}
+	forwardedIPConfig := &awswafv2.CfnWebACL_ForwardedIPConfigurationProperty{
+		HeaderName:       jsii.String(forwardedIPHeader),
+		FallbackBehavior: jsii.String("MATCH"),
+	}
+
+	switch rateLimit.AggregateKey {
+	case "", AggregateKeyIP:
+		statement.AggregateKeyType = jsii.String("IP")
+
+	case AggregateKeyForwardedIP:
+		statement.AggregateKeyType = jsii.String("FORWARDED_IP")
+		statement.ForwardedIpConfig = forwardedIPConfig
+
+	case AggregateKeyHeader:
+		// A single header is a CUSTOM_KEYS aggregation with one key
+		statement.AggregateKeyType = jsii.String("CUSTOM_KEYS")
+		statement.CustomKeys = rateLimitCustomKeys([]RateLimitCustomKey{
+			{Type: RateLimitKeyHeader, Name: rateLimit.AggregateHeaderName},
+		})
+
+	case AggregateKeyCustomKeys:
+		statement.AggregateKeyType = jsii.String("CUSTOM_KEYS")
+		statement.CustomKeys = rateLimitCustomKeys(rateLimit.CustomKeys)
+		for _, key := range rateLimit.CustomKeys {
+			if key.Type == RateLimitKeyForwardedIP {
+				statement.ForwardedIpConfig = forwardedIPConfig
+			}
+		}
+	}
+
+	return statement
+}
+
+// rateLimitScopeDown combines path prefix, method and header matches with AND.
+// Returns nil when the rate limit has no scope
+func rateLimitScopeDown(rateLimit ScopedRateLimit) *awswafv2.CfnWebACL_StatementProperty {
+	statements := make([]interface{}, 0, 3)
+
+	if rateLimit.PathPrefix != "" {
+		statements = append(statements, &awswafv2.CfnWebACL_StatementProperty{
+			ByteMatchStatement: uriPathMatch(strings.ToLower(rateLimit.PathPrefix), "STARTS_WITH"),
+		})
+	}
+
+	if rateLimit.Method != "" {
+		statements = append(statements, &awswafv2.CfnWebACL_StatementProperty{
+			ByteMatchStatement: byteMatchStatement(&ByteMatchRule{
+				Field:                MatchFieldMethod,
+				SearchString:         strings.ToUpper(rateLimit.Method),
+				PositionalConstraint: "EXACTLY",
+			}),
+		})
+	}
+
+	if rateLimit.Header != nil {
+		statements = append(statements, &awswafv2.CfnWebACL_StatementProperty{
+			ByteMatchStatement: byteMatchStatement(&ByteMatchRule{
+				Field:                MatchFieldHeader,
+				FieldName:            rateLimit.Header.Name,
+				SearchString:         rateLimit.Header.Value,
+				PositionalConstraint: "EXACTLY",
+			}),
+		})
+	}
+
+	switch len(statements) {
+	case 0:
+		return nil
+	case 1:
+		return statements[0].(*awswafv2.CfnWebACL_StatementProperty)
+	default:
+		return &awswafv2.CfnWebACL_StatementProperty{
+			AndStatement: &awswafv2.CfnWebACL_AndStatementProperty{
+				Statements: &statements,
+			},
+		}
+	}
+}
+
+// rateLimitCustomKeys converts custom keys into the CloudFormation custom keys
+func rateLimitCustomKeys(keys []RateLimitCustomKey) *[]interface{} {
+	noTransformation := textTransformations(nil)
+
+	customKeys := make([]interface{}, len(keys))
+	for i, key := range keys {
+		customKey := &awswafv2.CfnWebACL_RateBasedStatementCustomKeyProperty{}
+
+		switch key.Type {
+		case RateLimitKeyIP:
+			customKey.Ip = map[string]interface{}{}
+		case RateLimitKeyForwardedIP:
+			customKey.ForwardedIp = map[string]interface{}{}
+		case RateLimitKeyHTTPMethod:
+			customKey.HttpMethod = map[string]interface{}{}
+		case RateLimitKeyHeader:
+			customKey.Header = &awswafv2.CfnWebACL_RateLimitHeaderProperty{
+				Name:                jsii.String(strings.ToLower(key.Name)),
+				TextTransformations: noTransformation,
+			}
+		case RateLimitKeyCookie:
+			customKey.Cookie = &awswafv2.CfnWebACL_RateLimitCookieProperty{
+				Name:                jsii.String(key.Name),
+				TextTransformations: noTransformation,
+			}
+		case RateLimitKeyQueryArgument:
+			customKey.QueryArgument = &awswafv2.CfnWebACL_RateLimitQueryArgumentProperty{
+				Name:                jsii.String(key.Name),
+				TextTransformations: noTransformation,
+			}
+		case RateLimitKeyQueryString:
+			customKey.QueryString = &awswafv2.CfnWebACL_RateLimitQueryStringProperty{
+				TextTransformations: noTransformation,
+			}
+		case RateLimitKeyURIPath:
+			customKey.UriPath = &awswafv2.CfnWebACL_RateLimitUriPathProperty{
+				TextTransformations: noTransformation,
+			}
+		case RateLimitKeyLabelNamespace:
+			customKey.LabelNamespace = &awswafv2.CfnWebACL_RateLimitLabelNamespaceProperty{
+				Namespace: jsii.String(key.Name),
+			}
+		default:
+			panic(fmt.Sprintf("Unsupported rate limit custom key type: %s", key.Type))
+		}
+
+		customKeys[i] = customKey
+	}
+	return &customKeys
+}
+
+// validateScopedRateLimit fails synthesis on incomplete rate limit declarations
+func validateScopedRateLimit(rateLimit ScopedRateLimit) {
+	if rateLimit.Name == "" {
+		panic("RateLimits: every rate limit requires a Name")
+	}
+	if rateLimit.Limit < 10 {
+		panic(fmt.Sprintf("RateLimits: %s requires Limit >= 10", rateLimit.Name))
+	}
+
+	switch rateLimit.EvaluationWindowSec {
+	case 0, 60, 120, 300, 600:
+	default:
+		panic(fmt.Sprintf("RateLimits: %s EvaluationWindowSec must be 60, 120, 300 or 600", rateLimit.Name))
+	}
+
+	if rateLimit.Header != nil && (rateLimit.Header.Name == "" || rateLimit.Header.Value == "") {
+		panic(fmt.Sprintf("RateLimits: %s Header requires Name and Value", rateLimit.Name))
+	}
+
+	switch rateLimit.AggregateKey {
+	case "", AggregateKeyIP, AggregateKeyForwardedIP:
+	case AggregateKeyHeader:
+		if rateLimit.AggregateHeaderName == "" {
+			panic(fmt.Sprintf("RateLimits: %s with AggregateKeyHeader requires AggregateHeaderName", rateLimit.Name))
+		}
+	case AggregateKeyCustomKeys:
+		if len(rateLimit.CustomKeys) == 0 || len(rateLimit.CustomKeys) > 5 {
+			panic(fmt.Sprintf("RateLimits: %s with AggregateKeyCustomKeys requires between 1 and 5 CustomKeys", rateLimit.Name))
+		}
+		for _, key := range rateLimit.CustomKeys {
+			switch key.Type {
+			case RateLimitKeyHeader, RateLimitKeyCookie, RateLimitKeyQueryArgument, RateLimitKeyLabelNamespace:
+				if key.Name == "" {
+					panic(fmt.Sprintf("RateLimits: %s custom key %s requires Name", rateLimit.Name, key.Type))
+				}
+			}
+		}
+	default:
+		panic(fmt.Sprintf("RateLimits: %s has unsupported AggregateKey %s", rateLimit.Name, rateLimit.AggregateKey))
+	}
+
+	if rateLimit.ResponseBody != "" && rateLimit.ResponseBodyKey != "" {
+		panic(fmt.Sprintf("RateLimits: %s sets both ResponseBody and ResponseBodyKey", rateLimit.Name))
+	}
+}
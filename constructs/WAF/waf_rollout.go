@@ -0,0 +1,107 @@
+package waf
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awswafv2"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// RuleActionOverride changes the action of a single rule.
+//
+// With RuleGroup set, Rule is a rule inside that managed rule group
+// (e.g. "AWSManagedRulesCommonRuleSet" / "SizeRestrictions_BODY").
+// With RuleGroup empty, Rule is a rule of the Web ACL itself (e.g. "RateLimitRule")
+type RuleActionOverride struct {
+	RuleGroup string
+	Rule      string
+	Action    RuleAction
+}
+
+// rolloutState tracks which rollout settings were applied, so typos in group
+// or rule names fail synthesis instead of being silently ignored
+type rolloutState struct {
+	appliedVersions  map[string]bool
+	appliedOverrides map[int]bool
+}
+
+// applyManagedRuleGroupSettings pins the version and merges the rule action
+// overrides configured in props for the group referenced by statement
+func (b *ruleBuilder) applyManagedRuleGroupSettings(statement *awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty) {
+	groupName := *statement.Name
+
+	// Pinned version (an explicit version in the statement wins)
+	if version, ok := b.props.ManagedRuleGroupVersions[groupName]; ok {
+		b.rollout.appliedVersions[groupName] = true
+		if statement.Version == nil {
+			statement.Version = jsii.String(version)
+		}
+	}
+
+	// Per-rule action overrides, replacing overrides the strategy already set for the same rule
+	overrides := make([]interface{}, 0)
+	overridden := make(map[string]bool)
+	for i, override := range b.props.RuleActionOverrides {
+		if override.RuleGroup != groupName {
+			continue
+		}
+		b.rollout.appliedOverrides[i] = true
+		overridden[override.Rule] = true
+		overrides = append(overrides, &awswafv2.CfnWebACL_RuleActionOverrideProperty{
+			Name:        jsii.String(override.Rule),
+			ActionToUse: ruleAction(override.Action),
+		})
+	}
+
+	if len(overrides) == 0 {
+		return
+	}
+
+	if statement.RuleActionOverrides != nil {
+		for _, existing := range *statement.RuleActionOverrides.(*[]interface{}) {
+			if !overridden[*existing.(*awswafv2.CfnWebACL_RuleActionOverrideProperty).Name] {
+				overrides = append(overrides, existing)
+			}
+		}
+	}
+	statement.RuleActionOverrides = &overrides
+}
+
+// webACLRuleActionOverride returns the overridden action for a Web ACL rule, or nil
+func (b *ruleBuilder) webACLRuleActionOverride(ruleName string) *awswafv2.CfnWebACL_RuleActionProperty {
+	for i, override := range b.props.RuleActionOverrides {
+		if override.RuleGroup == "" && override.Rule == ruleName {
+			b.rollout.appliedOverrides[i] = true
+			return ruleAction(override.Action)
+		}
+	}
+	return nil
+}
+
+// validateRolloutSettings fails synthesis when a pinned version or an override
+// references a rule group or rule that is not part of the Web ACL
+func (b *ruleBuilder) validateRolloutSettings() {
+	groups := make([]string, 0)
+	for groupName := range b.props.ManagedRuleGroupVersions {
+		if !b.rollout.appliedVersions[groupName] {
+			groups = append(groups, groupName)
+		}
+	}
+	if len(groups) > 0 {
+		sort.Strings(groups)
+		panic(fmt.Sprintf("ManagedRuleGroupVersions: rule groups %v are not used by Web ACL %s", groups, b.webACLName))
+	}
+
+	for i, override := range b.props.RuleActionOverrides {
+		if override.Rule == "" {
+			panic("RuleActionOverrides: every override requires Rule")
+		}
+		if !b.rollout.appliedOverrides[i] {
+			if override.RuleGroup == "" {
+				panic(fmt.Sprintf("RuleActionOverrides: rule %s is not part of Web ACL %s", override.Rule, b.webACLName))
+			}
+			panic(fmt.Sprintf("RuleActionOverrides: rule group %s is not used by Web ACL %s", override.RuleGroup, b.webACLName))
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package waf
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2/awswafv2"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// ruleBuilder accumulates the rules of a Web ACL and assigns priorities in the
+// order rules are added. It is shared by every profile strategy so rule shapes,
+// visibility settings and ACL-level options are defined in a single place.
+type ruleBuilder struct {
+	scope      constructs.Construct
+	id         string
+	props      WAFFactoryProps
+	wafScope   string
+	webACLName string
+
+	rules     []interface{}
+	priority  int64
+	ruleNames map[string]bool
+
+	// Custom response bodies referenced by block actions (CustomResponseBodyKey)
+	customResponseBodies map[string]*awswafv2.CfnWebACL_CustomResponseBodyProperty
+
+	// Default action of the Web ACL (ALLOW unless a strategy changes it)
+	defaultAction RuleAction
+
+	// CAPTCHA/Challenge immunity time used when props don't set one (0 = WAF default)
+	defaultImmunityTime int64
+
+	// Pinned versions and action overrides applied so far
+	rollout rolloutState
+}
+
+// newRuleBuilder resolves the WAF scope and the Web ACL name (props.Name or id + defaultNameSuffix)
+func newRuleBuilder(scope constructs.Construct, id string, props WAFFactoryProps, defaultNameSuffix string) *ruleBuilder {
+	// Determine scope (CLOUDFRONT or REGIONAL)
+	wafScope := "CLOUDFRONT"
+	if props.Scope == ScopeRegional {
+		wafScope = "REGIONAL"
+	}
+
+	// Default name
+	webACLName := props.Name
+	if webACLName == "" {
+		webACLName = id + defaultNameSuffix
+	}
+
+	builder := &ruleBuilder{
+		scope:                scope,
+		id:                   id,
+		props:                props,
+		wafScope:             wafScope,
+		webACLName:           webACLName,
+		rules:                make([]interface{}, 0),
+		ruleNames:            make(map[string]bool),
+		customResponseBodies: make(map[string]*awswafv2.CfnWebACL_CustomResponseBodyProperty),
+		defaultAction:        RuleActionAllow,
+		rollout: rolloutState{
+			appliedVersions:  make(map[string]bool),
+			appliedOverrides: make(map[int]bool),
+		},
+	}
+
+	// Named response bodies must exist before any rule references them
+	builder.addPropsCustomResponseBodies()
+
+	return builder
+}
+
+// =============================================================================
+// RULES
+// =============================================================================
+
+// addRule appends a rule with the next priority. The metric name matches the rule name.
+// A RuleActionOverride for the rule name replaces action
+func (b *ruleBuilder) addRule(name string, statement *awswafv2.CfnWebACL_StatementProperty, action *awswafv2.CfnWebACL_RuleActionProperty) {
+	b.registerRuleName(name)
+
+	if override := b.webACLRuleActionOverride(name); override != nil {
+		action = override
+	}
+
+	b.rules = append(b.rules, &awswafv2.CfnWebACL_RuleProperty{
+		Name:             jsii.String(name),
+		Priority:         jsii.Number(b.priority),
+		Statement:        statement,
+		Action:           action,
+		VisibilityConfig: b.visibilityConfig(name),
+	})
+	b.priority++
+}
+
+// addManagedRuleGroup appends a managed rule group. With count=true (or RolloutMode)
+// the whole group only counts matches (OverrideAction count) instead of applying
+// its actions. Pinned versions and rule overrides from props are applied here
+func (b *ruleBuilder) addManagedRuleGroup(name string, statement *awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty, count bool) {
+	b.registerRuleName(name)
+	b.applyManagedRuleGroupSettings(statement)
+	count = count || b.props.RolloutMode
+
+	overrideAction := &awswafv2.CfnWebACL_OverrideActionProperty{
+		None: map[string]interface{}{},
+	}
+	if count {
+		overrideAction = &awswafv2.CfnWebACL_OverrideActionProperty{
+			Count: map[string]interface{}{},
+		}
+	}
+
+	b.rules = append(b.rules, &awswafv2.CfnWebACL_RuleProperty{
+		Name:     jsii.String(name),
+		Priority: jsii.Number(b.priority),
+		Statement: &awswafv2.CfnWebACL_StatementProperty{
+			ManagedRuleGroupStatement: statement,
+		},
+		OverrideAction:   overrideAction,
+		VisibilityConfig: b.visibilityConfig(name),
+	})
+	b.priority++
+}
+
+// registerRuleName fails synthesis on duplicate rule names (WAF requires unique names)
+func (b *ruleBuilder) registerRuleName(name string) {
+	if b.ruleNames[name] {
+		panic(fmt.Sprintf("WAF rule name %s is used more than once in Web ACL %s", name, b.webACLName))
+	}
+	b.ruleNames[name] = true
+}
+
+// addAWSManagedRuleGroups appends AWS managed rule groups with their default
+// actions, using the group name as rule name
+func (b *ruleBuilder) addAWSManagedRuleGroups(names ...string) {
+	for _, name := range names {
+		b.addManagedRuleGroup(name, awsManagedRuleGroup(name), false)
+	}
+}
+
+// addRateLimit appends a rate-based rule per IP that blocks with 429 (Too Many Requests)
+// and the RateLimitResponseBodyKey body if set. scopeDown is optional and limits
+// the requests that are counted
+func (b *ruleBuilder) addRateLimit(name string, limit int64, scopeDown *awswafv2.CfnWebACL_StatementProperty) {
+	b.addRule(name,
+		&awswafv2.CfnWebACL_StatementProperty{
+			RateBasedStatement: &awswafv2.CfnWebACL_RateBasedStatementProperty{
+				Limit:              jsii.Number(float64(limit)),
+				AggregateKeyType:   jsii.String("IP"),
+				ScopeDownStatement: scopeDown,
+			},
+		},
+		b.blockWithResponse(429, b.props.RateLimitResponseBodyKey),
+	)
+}
+
+// addGeoBlock appends a rule that blocks requests from the given countries
+func (b *ruleBuilder) addGeoBlock(name string, countryCodes []string) {
+	b.addRule(name,
+		&awswafv2.CfnWebACL_StatementProperty{
+			GeoMatchStatement: &awswafv2.CfnWebACL_GeoMatchStatementProperty{
+				CountryCodes: jsii.Strings(countryCodes...),
+			},
+		},
+		ruleAction(RuleActionBlock),
+	)
+}
+
+// addGeoAllow appends a rule that blocks every request NOT coming from the given countries
+func (b *ruleBuilder) addGeoAllow(name string, countryCodes []string) {
+	b.addRule(name,
+		notStatement(&awswafv2.CfnWebACL_StatementProperty{
+			GeoMatchStatement: &awswafv2.CfnWebACL_GeoMatchStatementProperty{
+				CountryCodes: jsii.Strings(countryCodes...),
+			},
+		}),
+		ruleAction(RuleActionBlock),
+	)
+}
+
+// addIPSetRule creates an IPv4 IP set (construct id: id + idSuffix, name:
+// webACLName + nameSuffix) and appends a rule that applies action to it
+func (b *ruleBuilder) addIPSetRule(name, idSuffix, nameSuffix, description string, addresses []string, action RuleAction) awswafv2.CfnIPSet {
+	ipSet := b.newIPSet(idSuffix, nameSuffix, description, "IPV4", addresses)
+
+	b.addRule(name,
+		&awswafv2.CfnWebACL_StatementProperty{
+			IpSetReferenceStatement: &awswafv2.CfnWebACL_IPSetReferenceStatementProperty{
+				Arn: ipSet.AttrArn(),
+			},
+		},
+		ruleAction(action),
+	)
+
+	return ipSet
+}
+
+// newIPSet creates an IP set in the Web ACL scope
+func (b *ruleBuilder) newIPSet(idSuffix, nameSuffix, description, ipAddressVersion string, addresses []string) awswafv2.CfnIPSet {
+	return awswafv2.NewCfnIPSet(b.scope, jsii.String(b.id+idSuffix), &awswafv2.CfnIPSetProps{
+		Name:             jsii.String(b.webACLName + nameSuffix),
+		Scope:            jsii.String(b.wafScope),
+		IpAddressVersion: jsii.String(ipAddressVersion),
+		Addresses:        jsii.Strings(addresses...),
+		Description:      jsii.String(description),
+	})
+}
+
+// addCustomResponseBody registers a response body that block actions reference by key.
+// contentType is TEXT_PLAIN, TEXT_HTML or APPLICATION_JSON
+func (b *ruleBuilder) addCustomResponseBody(key, contentType, content string) {
+	if existing, ok := b.customResponseBodies[key]; ok && (*existing.Content != content || *existing.ContentType != contentType) {
+		panic(fmt.Sprintf("WAF custom response body %s is defined twice with different content", key))
+	}
+
+	b.customResponseBodies[key] = &awswafv2.CfnWebACL_CustomResponseBodyProperty{
+		ContentType: jsii.String(contentType),
+		Content:     jsii.String(content),
+	}
+}
+
+// =============================================================================
+// WEB ACL
+// =============================================================================
+
+// webACLProps returns the Web ACL properties with the accumulated rules.
+// Strategies may adjust ACL-level settings before creating it
+func (b *ruleBuilder) webACLProps(description string) *awswafv2.CfnWebACLProps {
+	b.validateRolloutSettings()
+
+	defaultAction := &awswafv2.CfnWebACL_DefaultActionProperty{
+		Allow: &awswafv2.CfnWebACL_AllowActionProperty{},
+	}
+	if b.defaultAction == RuleActionBlock {
+		defaultAction = &awswafv2.CfnWebACL_DefaultActionProperty{
+			Block: &awswafv2.CfnWebACL_BlockActionProperty{},
+		}
+	}
+
+	webACLProps := &awswafv2.CfnWebACLProps{
+		Name:             jsii.String(b.webACLName),
+		Scope:            jsii.String(b.wafScope),
+		DefaultAction:    defaultAction,
+		Rules:            &b.rules,
+		VisibilityConfig: b.visibilityConfig(b.webACLName + "-Metrics"),
+		Description:      jsii.String(description),
+		CaptchaConfig:    b.captchaConfig(),
+		ChallengeConfig:  b.challengeConfig(),
+	}
+
+	if len(b.customResponseBodies) > 0 {
+		webACLProps.CustomResponseBodies = &b.customResponseBodies
+	}
+
+	return webACLProps
+}
+
+// build creates the Web ACL with the accumulated rules
+func (b *ruleBuilder) build(description string) awswafv2.CfnWebACL {
+	return awswafv2.NewCfnWebACL(b.scope, jsii.String(b.id), b.webACLProps(description))
+}
+
+// visibilityConfig honours EnableMetrics / EnableSampledRequests (both default to true)
+func (b *ruleBuilder) visibilityConfig(metricName string) *awswafv2.CfnWebACL_VisibilityConfigProperty {
+	return &awswafv2.CfnWebACL_VisibilityConfigProperty{
+		SampledRequestsEnabled:   jsii.Bool(boolOrDefault(b.props.EnableSampledRequests, true)),
+		CloudWatchMetricsEnabled: jsii.Bool(boolOrDefault(b.props.EnableMetrics, true)),
+		MetricName:               jsii.String(metricName),
+	}
+}
+
+// =============================================================================
+// STATEMENT AND ACTION HELPERS
+// =============================================================================
+
+// awsManagedRuleGroup references an AWS managed rule group by name
+func awsManagedRuleGroup(name string) *awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty {
+	return &awswafv2.CfnWebACL_ManagedRuleGroupStatementProperty{
+		VendorName: jsii.String("AWS"),
+		Name:       jsii.String(name),
+	}
+}
+
+// notStatement negates a statement
+func notStatement(statement *awswafv2.CfnWebACL_StatementProperty) *awswafv2.CfnWebACL_StatementProperty {
+	return &awswafv2.CfnWebACL_StatementProperty{
+		NotStatement: &awswafv2.CfnWebACL_NotStatementProperty{
+			Statement: statement,
+		},
+	}
+}
+
+// uriPathMatch builds a case-insensitive byte match on the request URI path
+func uriPathMatch(path string, positionalConstraint string) *awswafv2.CfnWebACL_ByteMatchStatementProperty {
+	return &awswafv2.CfnWebACL_ByteMatchStatementProperty{
+		FieldToMatch: &awswafv2.CfnWebACL_FieldToMatchProperty{
+			UriPath: map[string]interface{}{},
+		},
+		PositionalConstraint: jsii.String(positionalConstraint),
+		SearchString:         jsii.String(path),
+		TextTransformations: &[]*awswafv2.CfnWebACL_TextTransformationProperty{
+			{
+				Priority: jsii.Number(0),
+				Type:     jsii.String("LOWERCASE"),
+			},
+		},
+	}
+}
+
+// ruleAction converts a RuleAction into the CloudFormation rule action
+func ruleAction(action RuleAction) *awswafv2.CfnWebACL_RuleActionProperty {
+	switch action {
+	case RuleActionAllow:
+		return &awswafv2.CfnWebACL_RuleActionProperty{Allow: &awswafv2.CfnWebACL_AllowActionProperty{}}
+	case RuleActionBlock, "":
+		return &awswafv2.CfnWebACL_RuleActionProperty{Block: &awswafv2.CfnWebACL_BlockActionProperty{}}
+	case RuleActionCount:
+		return &awswafv2.CfnWebACL_RuleActionProperty{Count: &awswafv2.CfnWebACL_CountActionProperty{}}
+	case RuleActionCaptcha:
+		return &awswafv2.CfnWebACL_RuleActionProperty{Captcha: &awswafv2.CfnWebACL_CaptchaActionProperty{}}
+	case RuleActionChallenge:
+		return &awswafv2.CfnWebACL_RuleActionProperty{Challenge: &awswafv2.CfnWebACL_ChallengeActionProperty{}}
+	default:
+		panic(fmt.Sprintf("Unsupported WAF rule action: %s", action))
+	}
+}
+
+// blockWithStatus blocks with a custom HTTP status code (e.g. 429, 413)
+func blockWithStatus(statusCode float64) *awswafv2.CfnWebACL_RuleActionProperty {
+	return &awswafv2.CfnWebACL_RuleActionProperty{
+		Block: &awswafv2.CfnWebACL_BlockActionProperty{
+			CustomResponse: &awswafv2.CfnWebACL_CustomResponseProperty{
+				ResponseCode: jsii.Number(statusCode),
+			},
+		},
+	}
+}
+
+// boolOrDefault dereferences an optional bool
+func boolOrDefault(value *bool, defaultValue bool) bool {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
@@ -0,0 +1,168 @@
+package waf
+
+import (
+	"github.com/aws/aws-cdk-go/awscdk/v2/awswafv2"
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/aws/jsii-runtime-go"
+)
+
+// WAFWordPressStrategy implements WAF Web ACL optimized for WordPress sites
+// This profile combines the WordPress and PHP rule sets with the baseline rules
+// and protects the endpoints attackers target first (wp-login.php, xmlrpc.php, /wp-admin)
+//
+// Security Model:
+// - OWASP Top 10 protection via AWS Managed Rules
+// - WordPress-specific exploits (AWSManagedRulesWordPressRuleSet)
+// - PHP-specific exploits (AWSManagedRulesPHPRuleSet)
+// - SQL injection protection (WordPress runs on MySQL)
+// - Brute-force protection: dedicated rate limits for wp-login.php and xmlrpc.php
+// - /wp-admin restricted to admin IPs (admin-ajax.php stays public for themes/plugins)
+// - Optional geo-blocking, geo-allowlist and IP lists
+//
+// Use Cases:
+// - WordPress sites behind CloudFront or an ALB
+// - WooCommerce stores
+// - WordPress multisite installations
+//
+// Cost Estimate:
+// - Web ACL: $5/month
+// - Core Rule Set: $1/month
+// - Known Bad Inputs: $1/month
+// - WordPress Rule Set: $1/month
+// - PHP Rule Set: $1/month
+// - SQL Database: $1/month
+// - IP Reputation: $1/month
+// - Rate Limit Rules (global + login + xmlrpc): $3/month
+// - Requests: $0.60 per 1M requests
+// Total: ~$14-15/month + $0.60/1M requests
+type WAFWordPressStrategy struct{}
+
+// Build creates a WAF Web ACL configured for WordPress protection
+func (s *WAFWordPressStrategy) Build(scope constructs.Construct, id string, props WAFFactoryProps) awswafv2.CfnWebACL {
+
+	// =============================================================================
+	// WEB ACL CONFIGURATION - WordPress Protection
+	// =============================================================================
+	builder := newRuleBuilder(scope, id, props, "-WordPress-WebACL")
+
+	// RULE 1: Rate Limiting (default 2,000 req/5min per IP)
+	rateLimitValue := int64(2000)
+	if props.RateLimitRequests != nil && *props.RateLimitRequests > 0 {
+		rateLimitValue = *props.RateLimitRequests
+	}
+	builder.addRateLimit("RateLimitRule", rateLimitValue, nil)
+
+	// Scoped rate limits (per path, method or header) from props.RateLimits
+	builder.addScopedRateLimits()
+
+	// RULE 2: Geo Blocking (if specified)
+	if len(props.GeoBlockCountries) > 0 {
+		builder.addGeoBlock("GeoBlockingRule", props.GeoBlockCountries)
+	}
+
+	// RULE 3: Geo Allowlist (if specified)
+	// Blocks every request that does NOT come from the allowed countries
+	if len(props.GeoAllowCountries) > 0 {
+		builder.addGeoAllow("GeoAllowRule", props.GeoAllowCountries)
+	}
+
+	// RULE 4: IP Blocklist (if specified)
+	if len(props.BlockedIPs) > 0 {
+		builder.addIPSetRule("IPBlocklistRule", "BlockedIPSet", "-BlockedIPs", "Blocked IP addresses", props.BlockedIPs, RuleActionBlock)
+	}
+
+	// Dynamic blocklist fed by GuardDuty findings (if specified)
+	builder.addGuardDutyBlocklist()
+
+	// RULE 5: IP Allowlist (if specified)
+	// Allows specific IP addresses to bypass all other rules
+	if len(props.AllowedIPs) > 0 {
+		builder.addIPSetRule("IPAllowlistRule", "AllowedIPSet", "-AllowedIPs", "Allowed IP addresses (whitelist)", props.AllowedIPs, RuleActionAllow)
+	}
+
+	// =============================================================================
+	// RULES 6-7: Brute-force protection for wp-login.php and xmlrpc.php
+	// Dedicated (much lower) rate limits scoped down to each endpoint
+	// =============================================================================
+	loginRateLimit := int64(100)
+	if props.LoginRateLimitRequests != nil && *props.LoginRateLimitRequests > 0 {
+		loginRateLimit = *props.LoginRateLimitRequests
+	}
+
+	for _, endpoint := range []struct{ name, path string }{
+		{"WPLoginRateLimitRule", "/wp-login.php"},
+		{"XMLRPCRateLimitRule", "/xmlrpc.php"},
+	} {
+		builder.addRateLimit(endpoint.name, loginRateLimit, &awswafv2.CfnWebACL_StatementProperty{
+			ByteMatchStatement: uriPathMatch(endpoint.path, "ENDS_WITH"),
+		})
+	}
+
+	// =============================================================================
+	// RULE 8: /wp-admin restricted to admin IPs (if AdminAllowedIPs or AllowedIPs)
+	// Blocks /wp-admin/* from any other IP, except admin-ajax.php (used by the
+	// public site through themes and plugins)
+	// =============================================================================
+	adminIPs := props.AdminAllowedIPs
+	if len(adminIPs) == 0 {
+		adminIPs = props.AllowedIPs
+	}
+
+	if len(adminIPs) > 0 {
+		adminIPSet := builder.newIPSet("AdminIPSet", "-AdminIPs", "IP addresses allowed to reach /wp-admin", "IPV4", adminIPs)
+
+		builder.addRule("WPAdminIPRestrictionRule",
+			&awswafv2.CfnWebACL_StatementProperty{
+				AndStatement: &awswafv2.CfnWebACL_AndStatementProperty{
+					Statements: &[]interface{}{
+						&awswafv2.CfnWebACL_StatementProperty{
+							ByteMatchStatement: uriPathMatch("/wp-admin", "STARTS_WITH"),
+						},
+						notStatement(&awswafv2.CfnWebACL_StatementProperty{
+							ByteMatchStatement: uriPathMatch("/wp-admin/admin-ajax.php", "EXACTLY"),
+						}),
+						notStatement(&awswafv2.CfnWebACL_StatementProperty{
+							IpSetReferenceStatement: &awswafv2.CfnWebACL_IPSetReferenceStatementProperty{
+								Arn: adminIPSet.AttrArn(),
+							},
+						}),
+					},
+				},
+			},
+			ruleAction(RuleActionBlock),
+		)
+	}
+
+	// =============================================================================
+	// AWS MANAGED RULE GROUPS - WordPress Stack
+	// =============================================================================
+
+	// RULE 9: AWS Managed Rules - Core Rule Set (OWASP Top 10)
+	// SizeRestrictions_BODY is set to COUNT: media uploads and the block editor
+	// regularly send bodies larger than 8KB
+	commonRuleSet := awsManagedRuleGroup("AWSManagedRulesCommonRuleSet")
+	commonRuleSet.RuleActionOverrides = &[]interface{}{
+		&awswafv2.CfnWebACL_RuleActionOverrideProperty{
+			Name:        jsii.String("SizeRestrictions_BODY"),
+			ActionToUse: ruleAction(RuleActionCount),
+		},
+	}
+	builder.addManagedRuleGroup("AWSManagedRulesCommonRuleSet", commonRuleSet, false)
+
+	// RULES 10-14: WordPress, PHP, SQL Database, Known Bad Inputs and IP Reputation
+	builder.addAWSManagedRuleGroups(
+		"AWSManagedRulesWordPressRuleSet",
+		"AWSManagedRulesPHPRuleSet",
+		"AWSManagedRulesSQLiRuleSet",
+		"AWSManagedRulesKnownBadInputsRuleSet",
+		"AWSManagedRulesAmazonIpReputationList",
+	)
+
+	// Account takeover / account creation fraud prevention (if configured)
+	builder.addFraudPreventionRuleGroups()
+
+	// =============================================================================
+	// CREATE WEB ACL
+	// =============================================================================
+	return builder.build("Web Application Firewall for " + builder.webACLName + " - WordPress Protection")
+}
@@ -3,6 +3,7 @@ package stacks
 import (
 	"github.com/aws/aws-cdk-go/awscdk/v2"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awslogs"
+	"github.com/aws/aws-cdk-go/awscdk/v2/awsroute53"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awss3deployment"
 	"github.com/aws/constructs-go/constructs/v10"
 	"github.com/aws/jsii-runtime-go"
@@ -24,8 +25,15 @@ type StaticWebsiteStackProps struct {
 	DomainNames    []string
 	CertificateArn string
 
+	// Optional: Route53 hosted zone (looked up by domain name). When set, the
+	// distribution gets a DNS-validated certificate (unless CertificateArn is
+	// provided) and A/AAAA alias records for every domain. DomainNames defaults
+	// to the zone apex. Requires an explicit StackProps.Env (account and region).
+	HostedZoneDomain string
+
 	// Optional: CloudFront Configuration
 	PriceClass string
+	SiteMode   cloudfront.SiteMode // SiteModeSPA (default) or SiteModeStatic for multi-page sites with a real 404.html
 
 	// Optional: WAF Configuration
 	EnableWAF      bool                // Set to true to create WAF WebACL
@@ -33,6 +41,12 @@ type StaticWebsiteStackProps struct {
 }
 
 func NewStaticWebsiteStack(scope constructs.Construct, id string, props *StaticWebsiteStackProps) awscdk.Stack {
+	// A certificate created outside us-east-1 lives in an auxiliary stack that
+	// this stack references across regions
+	if props.HostedZoneDomain != "" && props.CertificateArn == "" {
+		props.StackProps.CrossRegionReferences = jsii.Bool(true)
+	}
+
 	stack := awscdk.NewStack(scope, &id, &props.StackProps)
 
 	// =============================================================================
@@ -71,21 +85,37 @@ func NewStaticWebsiteStack(scope constructs.Construct, id string, props *StaticW
 	}
 
 	// =============================================================================
-	// 3. CREATE CLOUDFRONT DISTRIBUTION USING FACTORY
+	// 3. LOOKUP HOSTED ZONE (Optional)
+	// =============================================================================
+	var hostedZone awsroute53.IHostedZone
+	domainNames := props.DomainNames
+	if props.HostedZoneDomain != "" {
+		hostedZone = awsroute53.HostedZone_FromLookup(stack, jsii.String("HostedZone"), &awsroute53.HostedZoneProviderProps{
+			DomainName: jsii.String(props.HostedZoneDomain),
+		})
+		if len(domainNames) == 0 {
+			domainNames = []string{props.HostedZoneDomain}
+		}
+	}
+
+	// =============================================================================
+	// 4. CREATE CLOUDFRONT DISTRIBUTION USING FACTORY
 	// =============================================================================
 	distribution := cloudfront.NewDistributionV2(stack, "WebsiteDistribution", cloudfront.CloudFrontPropertiesV2{
 		OriginType:                  cloudfront.OriginTypeS3,
 		S3Bucket:                    bucket,
-		DomainNames:                 props.DomainNames,
+		DomainNames:                 domainNames,
 		CertificateArn:              props.CertificateArn,
+		HostedZone:                  hostedZone,
 		WebAclArn:                   webAclArn,
 		Comment:                     props.WebsiteName + " - Static Website Distribution",
 		EnableAccessLogging:         false,
 		AutoConfigureS3BucketPolicy: true,
+		SiteMode:                    props.SiteMode,
 	})
 
 	// =============================================================================
-	// 4. DEPLOY CONTENT TO S3
+	// 5. DEPLOY CONTENT TO S3
 	// =============================================================================
 	deployment := awss3deployment.NewBucketDeployment(stack, jsii.String("WebsiteDeployment"), &awss3deployment.BucketDeploymentProps{
 		Sources: &[]awss3deployment.ISource{
@@ -110,7 +140,7 @@ func NewStaticWebsiteStack(scope constructs.Construct, id string, props *StaticW
 	deployment.Node().AddDependency(distribution)
 
 	// =============================================================================
-	// 5. STACK OUTPUTS
+	// 6. STACK OUTPUTS
 	// =============================================================================
 	if props.EnableWAF {
 		profileType := props.WafProfileType
@@ -143,8 +173,13 @@ func NewStaticWebsiteStack(scope constructs.Construct, id string, props *StaticW
 		ExportName:  jsii.String(props.WebsiteName + "-DistributionDomain"),
 	})
 
+	websiteHost := *distribution.DomainName()
+	if len(domainNames) > 0 {
+		websiteHost = domainNames[0]
+	}
+
 	awscdk.NewCfnOutput(stack, jsii.String("WebsiteURL"), &awscdk.CfnOutputProps{
-		Value:       jsii.String("https://" + *distribution.DomainName()),
+		Value:       jsii.String("https://" + websiteHost),
 		Description: jsii.String("Website URL"),
 		ExportName:  jsii.String(props.WebsiteName + "-WebsiteURL"),
 	})